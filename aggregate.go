@@ -0,0 +1,103 @@
+package assertly
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"math"
+)
+
+// Aggregate represents expected aggregated value of actual items field
+type Aggregate struct {
+	Function string
+	Path     string
+	Expected interface{}
+}
+
+// aggregateValues computes supplied aggregate function: sum, min, max, avg, count, distinct or abs
+func aggregateValues(name string, values []interface{}, timeLayout string) (interface{}, error) {
+	switch name {
+	case "count", "len":
+		return len(values), nil
+	case "distinct":
+		var distinct = make(map[string]bool)
+		for _, value := range values {
+			if value != nil {
+				distinct[toolbox.AsString(value)] = true
+			}
+		}
+		return len(distinct), nil
+	case "min", "max":
+		var result interface{}
+		for _, value := range values {
+			if value == nil {
+				continue
+			}
+			if result == nil {
+				result = value
+				continue
+			}
+			comparison := compareValues(value, result, timeLayout)
+			if (name == "min" && comparison < 0) || (name == "max" && comparison > 0) {
+				result = value
+			}
+		}
+		if number, err := toolbox.ToFloat(result); err == nil && !toolbox.IsBool(result) {
+			return number, nil
+		}
+		return result, nil
+	}
+	var numbers = make([]float64, 0, len(values))
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+		number, err := asNumber(value)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", name, err)
+		}
+		numbers = append(numbers, number)
+	}
+	switch name {
+	case "sum", "avg":
+		var sum = 0.0
+		for _, number := range numbers {
+			sum += number
+		}
+		if name == "sum" {
+			return sum, nil
+		}
+		if len(numbers) == 0 {
+			return nil, nil
+		}
+		return sum / float64(len(numbers)), nil
+	case "abs":
+		if len(numbers) != 1 {
+			return nil, fmt.Errorf("abs: expected 1 argument but had: %v", len(numbers))
+		}
+		return math.Abs(numbers[0]), nil
+	}
+	return nil, fmt.Errorf("unsupported function: %v", name)
+}
+
+func assertAggregates(aggregates []*Aggregate, actual interface{}, path DataPath, context *Context, validation *Validation) error {
+	timeLayout := path.Match(context).DefaultTimeLayout()
+	for _, aggregate := range aggregates {
+		var values = make([]interface{}, 0)
+		if selected, ok := selectValue(actual, aggregate.Path); ok {
+			if selected != nil && toolbox.IsSlice(selected) {
+				values = toolbox.AsSlice(selected)
+			} else {
+				values = append(values, selected)
+			}
+		}
+		aggregatePath := path.Key(fmt.Sprintf("%v(%v)", aggregate.Function, aggregate.Path))
+		aggregated, err := aggregateValues(aggregate.Function, values, timeLayout)
+		if err != nil {
+			return fmt.Errorf("failed to compute %v, path: %v, %v", aggregate.Function, aggregatePath.Path(), err)
+		}
+		if err := assertValue(aggregate.Expected, aggregated, aggregatePath, context, validation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
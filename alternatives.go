@@ -0,0 +1,104 @@
+package assertly
+
+import (
+	"github.com/viant/toolbox"
+	"strings"
+)
+
+// alternativesOf returns alternatives directive and its candidates if expected is {"@oneOf@": [...]} or {"@anyOf@": [...]} map,
+// other directives defined in the map are added to map candidates
+func alternativesOf(expected interface{}) (string, []interface{}, bool) {
+	if expected == nil || !toolbox.IsMap(expected) {
+		return "", nil, false
+	}
+	aMap := toolbox.AsMap(expected)
+	var directive string
+	var candidates []interface{}
+	var directives = make(map[string]interface{})
+	for key, value := range aMap {
+		switch {
+		case key == OneOfDirective || key == AnyOfDirective:
+			if directive != "" {
+				return "", nil, false
+			}
+			directive, candidates = key, append([]interface{}{}, asTemplates(value)...)
+		case strings.HasPrefix(key, "@") && strings.Count(key, "@") > 1:
+			directives[key] = value
+		default:
+			return "", nil, false
+		}
+	}
+	if directive == "" {
+		return "", nil, false
+	}
+	if len(directives) > 0 {
+		for i, candidate := range candidates {
+			if candidate == nil || !toolbox.IsMap(candidate) {
+				continue
+			}
+			var candidateMap = make(map[string]interface{})
+			for k, v := range directives {
+				candidateMap[k] = v
+			}
+			for k, v := range toolbox.AsMap(candidate) {
+				candidateMap[k] = v
+			}
+			candidates[i] = candidateMap
+		}
+	}
+	return directive, candidates, true
+}
+
+// matchCandidates asserts each expected candidate against actual value into isolated validation
+func matchCandidates(candidates []interface{}, actual interface{}, path DataPath, context *Context) ([]*itemMatch, error) {
+	var result = make([]*itemMatch, 0, len(candidates))
+	for i, candidate := range candidates {
+		match := &itemMatch{index: i, item: candidate, validation: newTrialValidation()}
+		if err := assertValue(cloneValue(candidate), cloneValue(actual), path, context, match.validation); err != nil {
+			return nil, err
+		}
+		result = append(result, match)
+	}
+	return result, nil
+}
+
+// assertAlternatives passes if any (@anyOf@) or exactly one (@oneOf@) candidate matches actual,
+// otherwise it reports the closest candidate followed by its failures
+func assertAlternatives(directive string, candidates []interface{}, actual interface{}, path DataPath, context *Context, validation *Validation) error {
+	if text, ok := actual.(string); ok && (toolbox.IsCompleteJSON(text) || toolbox.IsNewLineDelimitedJSON(text)) {
+		actual = asDataStructure(text)
+	}
+	matches, err := matchCandidates(candidates, actual, path, context)
+	if err != nil {
+		return err
+	}
+	var matchedIndexes = make([]int, 0)
+	for _, match := range matches {
+		if match.matched() {
+			matchedIndexes = append(matchedIndexes, match.index)
+		}
+	}
+	var violation = AnyOfViolation
+	if directive == OneOfDirective {
+		violation = OneOfViolation
+		if len(matchedIndexes) > 1 {
+			validation.AddFailure(newFailure(path.Source(), path.Path(), violation, candidates, actual, matchedIndexes, -1, 0))
+			return nil
+		}
+	}
+	if first := firstMatch(matches); first != nil {
+		validation.PassedCount++
+		validation.mergeCaptures(first.validation)
+		return nil
+	}
+	closest := closestMatch(matches)
+	if closest == nil {
+		validation.AddFailure(newFailure(path.Source(), path.Path(), violation, candidates, actual, matchedIndexes, -1, 0))
+		return nil
+	}
+	validation.AddFailure(newFailure(path.Source(), path.Path(), violation, candidates, actual, matchedIndexes, closest.index, closest.validation.FailedCount))
+	for _, failure := range closest.validation.Failures {
+		validation.AddFailure(failure)
+	}
+	return nil
+}
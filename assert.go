@@ -1,6 +1,7 @@
 package assertly
 
 import (
+	goContext "context"
 	"fmt"
 	"testing"
 )
@@ -29,6 +30,11 @@ func AssertValuesWithContext(context *Context, t *testing.T, expected, actual in
 	return true
 }
 
+//AssertValuesWithCtx validates expected against actual data structure with context bound to standard context
+func AssertValuesWithCtx(ctx goContext.Context, context *Context, t *testing.T, expected, actual interface{}, arguments ...interface{}) bool {
+	return AssertValuesWithContext(context.WithContext(ctx), t, expected, actual, arguments...)
+}
+
 func handlerValidationError(t *testing.T, err error, arguments ...interface{}) bool {
 	if len(arguments) > 0 {
 		handleFailure(t, fmt.Sprint(arguments...))
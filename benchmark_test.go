@@ -0,0 +1,110 @@
+package assertly_test
+
+import (
+	"fmt"
+	"github.com/viant/assertly"
+	"runtime"
+	"testing"
+)
+
+type benchmarkItem struct {
+	ID     int
+	Name   string
+	Score  float64
+	Active bool
+	Tags   []string
+}
+
+func benchmarkFixture(count int, indexed bool, mismatchEvery int) ([]interface{}, []interface{}, []interface{}) {
+	var expected = make([]interface{}, 0, count+1)
+	if indexed {
+		expected = append(expected, map[string]interface{}{"@indexBy@": "ID"})
+	}
+	var actual = make([]interface{}, 0, count)
+	var structs = make([]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		expected = append(expected, map[string]interface{}{
+			"ID":     i,
+			"Name":   fmt.Sprintf("name %v", i),
+			"Score":  float64(i) / 2,
+			"Active": i%2 == 0,
+			"Tags":   []interface{}{"a", "b"},
+		})
+		name := fmt.Sprintf("name %v", i)
+		if mismatchEvery > 0 && i%mismatchEvery == 0 {
+			name = "mismatch"
+		}
+		actual = append(actual, map[string]interface{}{
+			"ID":     i,
+			"Name":   name,
+			"Score":  float64(i) / 2,
+			"Active": i%2 == 0,
+			"Tags":   []interface{}{"a", "b"},
+		})
+		structs = append(structs, &benchmarkItem{ID: i, Name: name, Score: float64(i) / 2, Active: i%2 == 0, Tags: []string{"a", "b"}})
+	}
+	return expected, actual, structs
+}
+
+func runBenchmark(b *testing.B, indexed bool, useStructs bool, mismatchEvery int, parallelism int) {
+	for _, count := range []int{10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("%v", count), func(b *testing.B) {
+			if testing.Short() && count > 10000 {
+				b.Skip("fixture too large for short mode")
+			}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				expected, actual, structs := benchmarkFixture(count, indexed, mismatchEvery)
+				if useStructs {
+					actual = structs
+				}
+				context := assertly.NewDefaultContext()
+				context.Parallelism = parallelism
+				b.StartTimer()
+				if _, err := assertly.AssertWithContext(expected, actual, assertly.NewDataPath("/"), context); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkAssertSlice(b *testing.B) {
+	runBenchmark(b, false, false, 0, 0)
+}
+
+func BenchmarkAssertSliceIndexed(b *testing.B) {
+	runBenchmark(b, true, false, 0, 0)
+}
+
+func BenchmarkAssertSliceStructs(b *testing.B) {
+	runBenchmark(b, false, true, 0, 0)
+}
+
+func BenchmarkAssertSliceFailures(b *testing.B) {
+	runBenchmark(b, false, false, 10, 0)
+}
+
+func BenchmarkAssertSliceParallel(b *testing.B) {
+	runBenchmark(b, false, false, 0, runtime.NumCPU())
+}
+
+func BenchmarkAssertSliceReused(b *testing.B) {
+	for _, count := range []int{10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("%v", count), func(b *testing.B) {
+			if testing.Short() && count > 10000 {
+				b.Skip("fixture too large for short mode")
+			}
+			expected, actual, _ := benchmarkFixture(count, false, 0)
+			context := assertly.NewDefaultContext()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := assertly.AssertWithContext(expected, actual, assertly.NewDataPath("/"), context); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package assertly
+
+import (
+	"reflect"
+	"sync"
+)
+
+// maxCacheSize limits number of cached expected nodes and expressions, cache is reset once the limit is reached
+const maxCacheSize = 1 << 16
+
+// directiveEntry represents an expected map entry defining a directive
+type directiveEntry struct {
+	key   string
+	value interface{}
+}
+
+// directiveEntries represents directive entries of an expected map
+type directiveEntries struct {
+	node           map[string]interface{} //keeps the node referenced, so that its address is not reused while cached
+	size           int
+	directiveCount int
+	items          []*directiveEntry
+	index          map[string]*directiveEntry
+}
+
+// isDirectiveEntry returns true if supplied key is a directive key candidate or value is a directive value
+func isDirectiveEntry(key string, value interface{}) bool {
+	if len(key) > 0 && key[0] == '@' {
+		return true
+	}
+	text, ok := value.(string)
+	return ok && isDirectiveValue(text)
+}
+
+// newDirectiveEntries returns directive keys and keys with directive values of supplied map
+func newDirectiveEntries(aMap map[string]interface{}) *directiveEntries {
+	var result = &directiveEntries{node: aMap, size: len(aMap), index: make(map[string]*directiveEntry)}
+	for k, v := range aMap {
+		if !isDirectiveEntry(k, v) {
+			continue
+		}
+		if isDirectiveKey(k) {
+			result.directiveCount++
+		}
+		entry := &directiveEntry{key: k, value: v}
+		result.items = append(result.items, entry)
+		result.index[k] = entry
+	}
+	return result
+}
+
+// isCurrent returns true if supplied map has exactly the same directive entries as the cached node,
+// all map entries are checked, so that any key replaced with a directive key or value invalidates the entries
+func (e *directiveEntries) isCurrent(aMap map[string]interface{}) bool {
+	if e.size != len(aMap) {
+		return false
+	}
+	matched := 0
+	for k, v := range aMap {
+		if !isDirectiveEntry(k, v) {
+			continue
+		}
+		entry, ok := e.index[k]
+		if !ok || !isSameValue(v, entry.value) {
+			return false
+		}
+		matched++
+	}
+	return matched == len(e.items)
+}
+
+// isSameValue returns true if both values are the same scalar value or reference the same map or slice
+func isSameValue(value1, value2 interface{}) bool {
+	if text1, ok := value1.(string); ok {
+		text2, ok := value2.(string)
+		return ok && text1 == text2
+	}
+	reflectValue1, reflectValue2 := reflect.ValueOf(value1), reflect.ValueOf(value2)
+	if !reflectValue1.IsValid() || !reflectValue2.IsValid() {
+		return !reflectValue1.IsValid() && !reflectValue2.IsValid()
+	}
+	if reflectValue1.Type() != reflectValue2.Type() {
+		return false
+	}
+	switch reflectValue1.Kind() {
+	case reflect.Map, reflect.Ptr:
+		return reflectValue1.Pointer() == reflectValue2.Pointer()
+	case reflect.Slice:
+		return reflectValue1.Pointer() == reflectValue2.Pointer() && reflectValue1.Len() == reflectValue2.Len()
+	}
+	return reflectValue1.Type().Comparable() && value1 == value2
+}
+
+// assertCache caches directive entries per expected node and parsed expressions per text
+type assertCache struct {
+	mutex       sync.RWMutex
+	nodes       map[uintptr]*directiveEntries
+	expressions map[string]*expression
+}
+
+func (c *assertCache) directiveEntries(aMap map[string]interface{}) *directiveEntries {
+	key := reflect.ValueOf(aMap).Pointer()
+	c.mutex.RLock()
+	entries, ok := c.nodes[key]
+	c.mutex.RUnlock()
+	if ok && entries.isCurrent(aMap) {
+		return entries
+	}
+	entries = newDirectiveEntries(aMap)
+	c.mutex.Lock()
+	if len(c.nodes) >= maxCacheSize {
+		c.nodes = make(map[uintptr]*directiveEntries)
+	}
+	c.nodes[key] = entries
+	c.mutex.Unlock()
+	return entries
+}
+
+func (c *assertCache) expression(text string) (*expression, error) {
+	c.mutex.RLock()
+	expr, ok := c.expressions[text]
+	c.mutex.RUnlock()
+	if ok {
+		return expr, nil
+	}
+	expr, err := parseExpression(text)
+	if err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	if len(c.expressions) >= maxCacheSize {
+		c.expressions = make(map[string]*expression)
+	}
+	c.expressions[text] = expr
+	c.mutex.Unlock()
+	return expr, nil
+}
+
+func newAssertCache() *assertCache {
+	return &assertCache{
+		nodes:       make(map[uintptr]*directiveEntries),
+		expressions: make(map[string]*expression),
+	}
+}
+
+// directiveEntries returns directive entries of supplied expected map, entries are cached per expected node;
+// cache requires context created with NewContext
+func (c *Context) directiveEntries(aMap map[string]interface{}) *directiveEntries {
+	if c.cache == nil {
+		return newDirectiveEntries(aMap)
+	}
+	return c.cache.directiveEntries(aMap)
+}
+
+// expression returns parsed expression, parsed expressions are cached per text
+func (c *Context) expression(text string) (*expression, error) {
+	if c.cache == nil {
+		return parseExpression(text)
+	}
+	return c.cache.expression(text)
+}
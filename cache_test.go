@@ -0,0 +1,47 @@
+package assertly
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestContext_DirectiveEntries(t *testing.T) {
+	context := NewDefaultContext()
+	expected := map[string]interface{}{"@indexBy@": "id", "id": "@exists@", "name": "abc"}
+
+	entries := context.directiveEntries(expected)
+	assert.EqualValues(t, 2, len(entries.items))
+	assert.True(t, entries == context.directiveEntries(expected), "entries should be cached per expected node")
+
+	expected["@indexBy@"] = "name"
+	updated := context.directiveEntries(expected)
+	assert.False(t, entries == updated, "changed directive should invalidate cached entries")
+	directive := &Directive{}
+	directive.extractEntries(updated)
+	assert.EqualValues(t, []string{"name"}, directive.IndexBy)
+
+	expected["@sortText@"] = true
+	assert.EqualValues(t, 3, len(context.directiveEntries(expected).items))
+
+	delete(expected, "name")
+	expected["@strictMapCheck@"] = true
+	replaced := context.directiveEntries(expected)
+	assert.EqualValues(t, 4, len(replaced.items), "non directive key replaced with directive key should invalidate cached entries")
+	directive = &Directive{}
+	directive.extractEntries(replaced)
+	assert.True(t, directive.StrictMapCheck)
+
+	expected["id"] = 1
+	assert.EqualValues(t, 3, len(context.directiveEntries(expected).items), "directive value replaced with a value should invalidate cached entries")
+}
+
+func TestContext_Expression(t *testing.T) {
+	context := NewDefaultContext()
+	expr, err := context.expression("a > 1")
+	if assert.Nil(t, err) {
+		cached, _ := context.expression("a > 1")
+		assert.True(t, expr == cached)
+	}
+	_, err = context.expression("a >")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,99 @@
+package assertly
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/toolbox"
+)
+
+var goContextKey = (*context.Context)(nil)
+
+// CanceledError represents assertion interrupted by context cancellation or deadline
+type CanceledError struct {
+	Path string
+	Err  error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("assertion was canceled at %v: %v", e.Path, e.Err)
+}
+
+// Unwrap returns context error
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// IsCanceledError returns true if error was caused by context cancellation or deadline
+func IsCanceledError(err error) bool {
+	_, ok := err.(*CanceledError)
+	return ok
+}
+
+// GoContext returns standard context of the assertion for supplied value provider context, or background context
+func GoContext(ctx toolbox.Context) context.Context {
+	if ctx != nil && ctx.Contains(goContextKey) {
+		if result, ok := ctx.GetOptional(goContextKey).(*context.Context); ok && *result != nil {
+			return *result
+		}
+	}
+	return context.Background()
+}
+
+// WithContext returns a copy of the context bound to supplied standard context,
+// assertion checks cancellation during traversal and exposes it to value providers with GoContext
+func (c *Context) WithContext(ctx context.Context) *Context {
+	var result = *c
+	result.ctx = ctx
+	return &result
+}
+
+// canceled returns an error if assertion standard context was canceled or its deadline exceeded
+func (c *Context) canceled(path DataPath) error {
+	if c.ctx == nil {
+		return nil
+	}
+	select {
+	case <-c.ctx.Done():
+		return &CanceledError{Path: path.Path(), Err: c.ctx.Err()}
+	default:
+		return nil
+	}
+}
+
+// expand expands macro with the context lock, when bound to a standard context,
+// macro expansion is abandoned once the standard context is canceled; abandoned expansion still holds the lock
+// until value provider returns (the lock can not be released earlier as evaluator shares the toolbox context),
+// so that value providers should honour GoContext cancellation
+func (c *Context) expand(text string, path DataPath) (interface{}, error) {
+	if c.ctx == nil {
+		unlock := c.lock()
+		defer unlock()
+		return c.Evaluator.Expand(c.Context, text)
+	}
+	type expanded struct {
+		value interface{}
+		err   error
+	}
+	var done = make(chan *expanded, 1)
+	go func() {
+		unlock := c.lock()
+		defer unlock()
+		var ctx = c.ctx
+		_ = c.Context.Replace(goContextKey, &ctx)
+		value, err := c.Evaluator.Expand(c.Context, text)
+		c.Context.Remove(goContextKey)
+		done <- &expanded{value: value, err: err}
+	}()
+	select {
+	case result := <-done:
+		return result.value, result.err
+	case <-c.ctx.Done():
+		return nil, &CanceledError{Path: path.Path(), Err: c.ctx.Err()}
+	}
+}
+
+// AssertWithCtx validates expected against actual data structure for supplied path and context bound to standard context,
+// on cancellation or deadline it returns partial validation with CanceledError
+func AssertWithCtx(ctx context.Context, expected, actual interface{}, path DataPath, aContext *Context) (*Validation, error) {
+	return AssertWithContext(expected, actual, path, aContext.WithContext(ctx))
+}
@@ -0,0 +1,75 @@
+package assertly_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"github.com/viant/toolbox"
+	"testing"
+	"time"
+)
+
+type waitValueProvider struct {
+	observed chan error
+}
+
+// Get waits for assertion context to be done, it reports context error observed by the provider
+func (p *waitValueProvider) Get(ctx toolbox.Context, arguments ...interface{}) (interface{}, error) {
+	select {
+	case <-assertly.GoContext(ctx).Done():
+		err := assertly.GoContext(ctx).Err()
+		p.observed <- err
+		return nil, err
+	case <-time.After(5 * time.Second):
+		p.observed <- nil
+		return "late", nil
+	}
+}
+
+func TestAssertWithCtx(t *testing.T) {
+	registry := toolbox.NewValueProviderRegistry()
+	provider := &waitValueProvider{observed: make(chan error, 1)}
+	registry.Register("wait", provider)
+	evaluator := toolbox.NewMacroEvaluator("<ds:", ">", registry)
+
+	{ //completed assertion
+		validation, err := assertly.AssertWithCtx(context.Background(), `[1, 2, 3]`, `[1, 2, 3]`, assertly.NewDataPath("/"), assertly.NewDefaultContext())
+		assert.Nil(t, err)
+		assert.EqualValues(t, 3, validation.PassedCount)
+	}
+	{ //canceled before assertion
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		validation, err := assertly.AssertWithCtx(ctx, `[1, 2, 3]`, `[1, 2, 3]`, assertly.NewDataPath("/"), assertly.NewDefaultContext())
+		assert.True(t, assertly.IsCanceledError(err))
+		assert.EqualValues(t, 0, validation.PassedCount)
+	}
+	{ //deadline exceeded in value provider
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		started := time.Now()
+		validation, err := assertly.AssertWithCtx(ctx, []interface{}{1, 2, "<ds:wait>", 4}, []interface{}{1, 2, 3, 4}, assertly.NewDataPath("/"), assertly.NewContext(nil, nil, evaluator))
+		assert.True(t, time.Since(started) < time.Second)
+		if assert.True(t, assertly.IsCanceledError(err)) {
+			assert.EqualValues(t, context.DeadlineExceeded, err.(*assertly.CanceledError).Err)
+			assert.EqualValues(t, "[/]:[2]", err.(*assertly.CanceledError).Path)
+		}
+		assert.EqualValues(t, 2, validation.PassedCount, "partial validation")
+		select {
+		case observed := <-provider.observed:
+			assert.EqualValues(t, context.DeadlineExceeded, observed, "provider should see assertion context")
+		case <-time.After(time.Second):
+			assert.Fail(t, "provider did not observe assertion context cancellation")
+		}
+	}
+	{ //parallel assertion
+		expected, actual := parallelTestData(100)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		aContext := assertly.NewDefaultContext()
+		aContext.Parallelism = 4
+		aContext.ParallelThreshold = 10
+		_, err := assertly.AssertWithCtx(ctx, expected, actual, assertly.NewDataPath("/"), aContext)
+		assert.True(t, assertly.IsCanceledError(err))
+	}
+}
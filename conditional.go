@@ -0,0 +1,103 @@
+package assertly
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"sort"
+	"strings"
+)
+
+const (
+	conditionWhenKey = "when"
+	conditionThenKey = "then"
+	conditionElseKey = "else"
+)
+
+// isConditionalKey returns true if key is @if@ or @when@ directive key
+func isConditionalKey(key string) bool {
+	return strings.HasPrefix(key, IfDirective) || strings.HasPrefix(key, WhenDirective)
+}
+
+// condition represents conditional expected entries
+type condition struct {
+	when     string
+	then     interface{}
+	elseThen interface{}
+}
+
+// conditionsOf returns conditions defined with supplied conditional directive key
+func conditionsOf(key string, value interface{}) ([]*condition, error) {
+	if strings.HasPrefix(key, WhenDirective) {
+		return []*condition{{when: strings.Replace(key, WhenDirective, "", 1), then: value}}, nil
+	}
+	var result = make([]*condition, 0)
+	for _, item := range asTemplates(value) {
+		if item == nil || !toolbox.IsMap(item) {
+			return nil, fmt.Errorf("%v should define %v, %v and optional %v, but had %T", key, conditionWhenKey, conditionThenKey, conditionElseKey, item)
+		}
+		aMap := toolbox.AsMap(item)
+		var when = strings.Replace(key, IfDirective, "", 1)
+		if when == "" {
+			when = toolbox.AsString(aMap[conditionWhenKey])
+		}
+		result = append(result, &condition{when: when, then: aMap[conditionThenKey], elseThen: aMap[conditionElseKey]})
+	}
+	return result, nil
+}
+
+// applyConditions returns expected map with @if@ and @when@ directives replaced by the entries selected with actual value,
+// condition that can not be evaluated with actual value is reported as violation and selects no entries
+func applyConditions(expected map[string]interface{}, actual interface{}, path DataPath, context *Context, validation *Validation) (map[string]interface{}, error) {
+	var keys = make([]string, 0)
+	for key := range expected {
+		if isConditionalKey(key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return expected, nil
+	}
+	sort.Strings(keys)
+	var result = make(map[string]interface{})
+	for k, v := range expected {
+		if !isConditionalKey(k) {
+			result[k] = v
+		}
+	}
+	timeLayout := path.Match(context).DefaultTimeLayout()
+	for _, key := range keys {
+		conditions, err := conditionsOf(key, expected[key])
+		if err != nil {
+			return nil, fmt.Errorf("%v, path: %v", err, path.Path())
+		}
+		for _, condition := range conditions {
+			expr, err := context.expression(condition.when)
+			if err != nil {
+				return nil, fmt.Errorf("%v, path: %v", err, path.Path())
+			}
+			matched, operands, err := expr.Evaluate(actual, timeLayout)
+			if err != nil {
+				validation.AddFailure(newFailure(path.Source(), path.Path(), PredicateViolation, condition.when, operands, err))
+				continue
+			}
+			var selected = condition.elseThen
+			if isTruthy(matched) {
+				selected = condition.then
+			}
+			if selected == nil {
+				continue
+			}
+			if !toolbox.IsMap(selected) {
+				return nil, fmt.Errorf("conditional entries should be map but was %T, path: %v", selected, path.Path())
+			}
+			entries, err := applyConditions(toolbox.AsMap(selected), actual, path, context, validation)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range entries {
+				result[k] = v
+			}
+		}
+	}
+	return result, nil
+}
@@ -1,7 +1,9 @@
 package assertly
 
 import (
+	"context"
 	"github.com/viant/toolbox"
+	"sync"
 )
 
 //Context represent validation context
@@ -10,7 +12,22 @@ type Context struct {
 	Directives *Directives
 	Evaluator  *toolbox.MacroEvaluator
 
+	//StrictDatTypeCheck disables matching expected text with actual time (and vice versa) by time value
 	StrictDatTypeCheck bool
+	//NoImplicitCasting reports incompatible data type violation instead of any implicit casting between expected and actual data types
+	NoImplicitCasting bool
+	//Parallelism sets number of workers asserting slice items and map entries concurrently, 0 or 1 asserts sequentially
+	Parallelism int
+	//ParallelThreshold sets minimum number of slice items or map entries to assert concurrently, DefaultParallelThreshold is used if not set
+	ParallelThreshold int
+	mutex             *sync.Mutex
+	ctx               context.Context
+	cache             *assertCache
+}
+
+//Variables returns actual values captured with capture directive or <ds:capture[name]> macro
+func (c *Context) Variables() Variables {
+	return contextVariables(c.Context)
 }
 
 //NewContext returns a context
@@ -28,6 +45,8 @@ func NewContext(ctx toolbox.Context, directives *Directives, evaluator *toolbox.
 		Context:    ctx,
 		Directives: directives,
 		Evaluator:  evaluator,
+		mutex:      contextMutex(ctx),
+		cache:      newAssertCache(),
 	}
 }
 
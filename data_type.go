@@ -0,0 +1,146 @@
+package assertly
+
+import (
+	"encoding/json"
+	"github.com/viant/toolbox"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	NullDataType   = "null"
+	StringDataType = "string"
+	NumberDataType = "number"
+	BoolDataType   = "bool"
+	ObjectDataType = "object"
+	ArrayDataType  = "array"
+	TimeDataType   = "time"
+)
+
+// dataTypeOf returns data type name of supplied value
+func dataTypeOf(value interface{}) string {
+	if value == nil {
+		return NullDataType
+	}
+	switch value.(type) {
+	case json.Number:
+		return NumberDataType
+	case time.Time, *time.Time:
+		return TimeDataType
+	}
+	reflectValue := reflect.ValueOf(value)
+	for reflectValue.Kind() == reflect.Ptr || reflectValue.Kind() == reflect.Interface {
+		if reflectValue.IsNil() {
+			return NullDataType
+		}
+		reflectValue = reflectValue.Elem()
+	}
+	switch reflectValue.Kind() {
+	case reflect.String:
+		return StringDataType
+	case reflect.Bool:
+		return BoolDataType
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return NumberDataType
+	case reflect.Map, reflect.Struct:
+		return ObjectDataType
+	case reflect.Slice, reflect.Array:
+		return ArrayDataType
+	}
+	return reflectValue.Kind().String()
+}
+
+func isIntegral(value interface{}) bool {
+	number, err := toolbox.ToFloat(value)
+	return err == nil && number == math.Trunc(number)
+}
+
+// matchDataType returns true if actual value has expected data type, alternatives can be separated with '|', i.e. string|null
+func matchDataType(expected string, actual interface{}) bool {
+	actualType := dataTypeOf(actual)
+	for _, candidate := range strings.Split(expected, "|") {
+		switch strings.ToLower(strings.TrimSpace(candidate)) {
+		case "any":
+			return true
+		case NullDataType, "nil":
+			if actualType == NullDataType {
+				return true
+			}
+		case StringDataType, "text":
+			if actualType == StringDataType {
+				return true
+			}
+		case NumberDataType, "float", "double", "numeric":
+			if actualType == NumberDataType {
+				return true
+			}
+		case "int", "integer":
+			if actualType == NumberDataType && isIntegral(actual) {
+				return true
+			}
+		case BoolDataType, "boolean":
+			if actualType == BoolDataType {
+				return true
+			}
+		case ObjectDataType, "map", "struct":
+			if actualType == ObjectDataType {
+				return true
+			}
+		case ArrayDataType, "slice", "list":
+			if actualType == ArrayDataType {
+				return true
+			}
+		case TimeDataType, "timestamp", "date":
+			if actualType == TimeDataType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isExpressionText returns true if expected text is an assertion expression rather than a literal value
+func isExpressionText(text string, context *Context) bool {
+	text = strings.TrimSpace(text)
+	if text == KeyExistsDirective || text == KeyDoesNotExistsDirective {
+		return true
+	}
+	if strings.HasPrefix(text, "!") || strings.HasPrefix(text, "~/") || (len(text) > 1 && strings.HasPrefix(text, "/") && strings.HasSuffix(text, "/")) {
+		return true
+	}
+	if context.Evaluator.HasMacro(text) {
+		return true
+	}
+	if !(strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[")) {
+		return false //bare JSON scalars like 1 or true are literal values
+	}
+	return toolbox.IsCompleteJSON(text) || toolbox.IsNewLineDelimitedJSON(text)
+}
+
+// isDataTypeCompatible returns true if actual data type is compatible with expected data type
+func isDataTypeCompatible(expected, actual interface{}, context *Context) bool {
+	if expected == nil || actual == nil || getPredicate(expected) != nil {
+		return true
+	}
+	if _, _, ok := alternativesOf(expected); ok {
+		return true
+	}
+	expectedType := dataTypeOf(expected)
+	actualType := dataTypeOf(actual)
+	if expectedType == actualType || actualType == NullDataType {
+		return true
+	}
+	switch expectedType {
+	case StringDataType:
+		return actualType == TimeDataType || isExpressionText(toolbox.AsString(expected), context)
+	case TimeDataType:
+		return actualType == StringDataType
+	case ObjectDataType, ArrayDataType:
+		return actualType == ObjectDataType || actualType == ArrayDataType || actualType == StringDataType
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package assertly
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_MatchDataType(t *testing.T) {
+	var text = "abc"
+	var useCases = []struct {
+		expected string
+		actual   interface{}
+		matched  bool
+	}{
+		{expected: "string", actual: "abc", matched: true},
+		{expected: "string", actual: &text, matched: true},
+		{expected: "string", actual: 1},
+		{expected: "number", actual: 1, matched: true},
+		{expected: "number", actual: 1.3, matched: true},
+		{expected: "int", actual: 1.0, matched: true},
+		{expected: "int", actual: 1.3},
+		{expected: "bool", actual: false, matched: true},
+		{expected: "object", actual: map[string]interface{}{}, matched: true},
+		{expected: "object", actual: struct{}{}, matched: true},
+		{expected: "array", actual: []int{}, matched: true},
+		{expected: "null", actual: nil, matched: true},
+		{expected: "string|null", actual: nil, matched: true},
+		{expected: "time", actual: time.Now(), matched: true},
+		{expected: "any", actual: 1, matched: true},
+	}
+	for _, useCase := range useCases {
+		assert.EqualValues(t, useCase.matched, matchDataType(useCase.expected, useCase.actual), useCase.expected)
+	}
+}
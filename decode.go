@@ -0,0 +1,163 @@
+package assertly
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	JSONDecoding       = "json"
+	Base64Decoding     = "base64"
+	GzipDecoding       = "gzip"
+	QueryDecoding      = "query"
+	URLEncodedDecoding = "urlencoded"
+	URLDecoding        = "url"
+)
+
+// decodingAliases maps encoding names to decoding steps
+var decodingAliases = map[string][]string{
+	"gzip+base64": {Base64Decoding, GzipDecoding},
+}
+
+// decodingSteps returns decoding steps, '+' separated steps are applied from left to right, i.e. base64+json
+func decodingSteps(decoding string) []string {
+	decoding = strings.ToLower(strings.TrimSpace(decoding))
+	if steps, ok := decodingAliases[decoding]; ok {
+		return steps
+	}
+	return strings.Split(decoding, "+")
+}
+
+func decodeBase64(text string) ([]byte, error) {
+	text = strings.TrimSpace(text)
+	var err error
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		var decoded []byte
+		if decoded, err = encoding.DecodeString(text); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, err
+}
+
+// ValuesMap converts multi values map, i.e. url.Values or http.Header, into a map where each key has a sorted list of values,
+// so that key shape does not depend on number of values and values order does not matter
+func ValuesMap(values map[string][]string) map[string]interface{} {
+	var result = make(map[string]interface{}, len(values))
+	for key, items := range values {
+		var sorted = append([]string{}, items...)
+		sort.Strings(sorted)
+		var list = make([]interface{}, len(sorted))
+		for i, item := range sorted {
+			list[i] = item
+		}
+		result[key] = list
+	}
+	return result
+}
+
+func decodeQuery(text string) (interface{}, error) {
+	values, err := url.ParseQuery(strings.TrimPrefix(strings.TrimSpace(text), "?"))
+	if err != nil {
+		return nil, err
+	}
+	return ValuesMap(values), nil
+}
+
+// decodeURL decomposes URL into scheme, user, host, hostname, port, path, query and fragment map, query is decoded with ValuesMap
+func decodeURL(text string) (interface{}, error) {
+	parsed, err := url.Parse(strings.TrimSpace(text))
+	if err != nil {
+		return nil, err
+	}
+	query, err := decodeQuery(parsed.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+	var user = ""
+	if parsed.User != nil {
+		user = parsed.User.Username()
+	}
+	return map[string]interface{}{
+		"scheme":   parsed.Scheme,
+		"user":     user,
+		"host":     parsed.Host,
+		"hostname": parsed.Hostname(),
+		"port":     parsed.Port(),
+		"path":     parsed.Path,
+		"query":    query,
+		"fragment": parsed.Fragment,
+	}, nil
+}
+
+// decodeValue decodes supplied value with decoding steps
+func decodeValue(value interface{}, decoding string) (interface{}, error) {
+	for _, step := range decodingSteps(decoding) {
+		var text string
+		switch actual := value.(type) {
+		case string:
+			text = actual
+		case []byte:
+			text = string(actual)
+		default:
+			return nil, fmt.Errorf("unable to %v decode %T", step, value)
+		}
+		var err error
+		switch strings.TrimSpace(step) {
+		case JSONDecoding:
+			value, err = toolbox.JSONToInterface(text)
+		case Base64Decoding:
+			value, err = decodeBase64(text)
+		case GzipDecoding:
+			var reader *gzip.Reader
+			if reader, err = gzip.NewReader(bytes.NewReader([]byte(text))); err == nil {
+				value, err = ioutil.ReadAll(reader)
+				_ = reader.Close()
+			}
+		case QueryDecoding:
+			value, err = decodeQuery(text)
+		case URLEncodedDecoding:
+			value, err = url.QueryUnescape(text)
+		case URLDecoding:
+			value, err = decodeURL(text)
+		default:
+			return nil, fmt.Errorf("unsupported decoding: %v", step)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to %v decode: %v", step, err)
+		}
+	}
+	if decoded, ok := value.([]byte); ok {
+		return string(decoded), nil
+	}
+	return value, nil
+}
+
+// decodeValues replaces actual values with decoded values, it returns keys that failed to decode
+func decodeValues(decodings map[string]string, actual map[string]interface{}, path DataPath, validation *Validation) map[string]bool {
+	var failed = make(map[string]bool)
+	actualMap := data.Map(actual)
+	for key, decoding := range decodings {
+		value, ok := actualMap.GetValue(key)
+		if !ok || value == nil {
+			continue
+		}
+		decoded, err := decodeValue(value, decoding)
+		if err != nil {
+			keyPath := path.Key(key)
+			validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), DecodeViolation, decoding, value, err))
+			failed[key] = true
+			continue
+		}
+		actualMap.SetValue(key, decoded)
+	}
+	return failed
+}
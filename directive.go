@@ -3,6 +3,8 @@ package assertly
 import (
 	"fmt"
 	"github.com/viant/toolbox"
+	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -24,13 +26,53 @@ const (
 	LengthDirective                = "@length@"
 	StrictMapCheckDirective        = "@strictMapCheck@"
 	ElapsedRangeDirective          = "@elapsedRange@"
+	SomeDirective                  = "@some@"
+	NoneDirective                  = "@none@"
+	CountDirective                 = "@count@"
+	TypeDirective                  = "@type@"
+	SchemaDirective                = "@schema@"
+	CaptureDirective               = "@capture@"
+	ExprDirective                  = "@expr@"
+	SumDirective                   = "@sum@"
+	MinDirective                   = "@min@"
+	MaxDirective                   = "@max@"
+	AvgDirective                   = "@avg@"
+	DistinctDirective              = "@distinct@"
+	UniqueDirective                = "@unique@"
+	UniqueByDirective              = "@uniqueBy@"
+	SortedByDirective              = "@sortedBy@"
+	SortByDirective                = "@sortBy@"
+	IfDirective                    = "@if@"
+	WhenDirective                  = "@when@"
+	OneOfDirective                 = "@oneOf@"
+	AnyOfDirective                 = "@anyOf@"
+	KeyCountDirective              = "@keyCount@"
+	OptionalDirective              = "@optional@"
+	NullableDirective              = "@nullable@"
+	NormalizeDirective             = "@normalize@"
+	AlignDirective                 = "@align@"
+	DecodeDirective                = "@decode@"
 )
 
+var aggregateDirectives = map[string]string{
+	SumDirective:      "sum",
+	MinDirective:      "min",
+	MaxDirective:      "max",
+	AvgDirective:      "avg",
+	DistinctDirective: "distinct",
+}
+
 type AssertPath struct {
 	SubPath  string
 	Expected interface{}
 }
 
+// CountMatch represents expected number of slice items matching expected template
+type CountMatch struct {
+	Expected interface{}
+	Count    interface{}
+}
+
 // Match represents a validation TestDirective
 type Directive struct {
 	DataPath
@@ -42,8 +84,25 @@ type Directive struct {
 	StrictMapCheck        bool
 	TimeLayouts           map[string]string
 	DataType              map[string]string
+	Types                 map[string]string
+	Schemas               map[string]interface{}
+	Captures              map[string]string
+	Exprs                 []string
+	Aggregates            []*Aggregate
+	Unique                bool
+	UniqueBy              []string
+	SortedBy              []string
+	SortBy                []string
+	KeyCounts             map[string]interface{}
+	Optional              map[string]bool
+	Nullable              map[string]bool
+	Normalize             []string
+	KeyNormalize          map[string][]string
+	Align                 bool
+	Decodings             map[string]string
 	ElaspedRange          map[string]string
 	Lengths               map[string]int
+	LengthExpressions     map[string]interface{}
 	SwitchBy              []string
 	CoalesceWithZero      bool
 	NumericPrecisionPoint *int
@@ -51,17 +110,28 @@ type Directive struct {
 	Source                string
 	SortText              bool
 	AssertPaths           []*AssertPath
+	Some                  []interface{}
+	None                  []interface{}
+	Counts                []*CountMatch
+	shared                sharedMaps
+}
+
+// sharedMaps flags maps inherited from a parent directive, a shared map is copied before the first write
+type sharedMaps struct {
+	dataType        bool
+	elapsedRange    bool
+	keyExists       bool
+	keyDoesNotExist bool
 }
 
 func (d *Directive) mergeFrom(source *Directive) {
 	if source == nil {
 		return
 	}
-	mergeTextMap(source.DataType, &d.DataType)
-	mergeTextMap(source.TimeLayouts, &d.TimeLayouts)
-	mergeTextMap(source.ElaspedRange, &d.ElaspedRange)
-	mergeBoolMap(source.KeyExists, &d.KeyExists)
-	mergeBoolMap(source.KeyDoesNotExist, &d.KeyDoesNotExist)
+	d.shared.dataType = shareTextMap(source.DataType, &d.DataType, d.shared.dataType)
+	d.shared.elapsedRange = shareTextMap(source.ElaspedRange, &d.ElaspedRange, d.shared.elapsedRange)
+	d.shared.keyExists = shareBoolMap(source.KeyExists, &d.KeyExists, d.shared.keyExists)
+	d.shared.keyDoesNotExist = shareBoolMap(source.KeyDoesNotExist, &d.KeyDoesNotExist, d.shared.keyDoesNotExist)
 	d.CoalesceWithZero = source.CoalesceWithZero
 	d.CaseSensitive = source.CaseSensitive
 	d.KeyCaseSensitive = source.KeyCaseSensitive
@@ -77,6 +147,37 @@ func (d *Directive) mergeFrom(source *Directive) {
 	if d.TimeLayout == "" {
 		d.TimeLayout = source.TimeLayout
 	}
+	if len(d.Normalize) == 0 {
+		d.Normalize = source.Normalize
+	}
+}
+
+// clone returns a copy of directive for supplied path with its own maps and slices, so that it can be modified independently
+func (d *Directive) clone(path DataPath) *Directive {
+	var result = *d
+	result.DataPath = path
+	result.shared = sharedMaps{}
+	value := reflect.ValueOf(&result).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		switch field.Kind() {
+		case reflect.Map:
+			if field.IsNil() {
+				continue
+			}
+			aMap := reflect.MakeMapWithSize(field.Type(), field.Len())
+			for _, key := range field.MapKeys() {
+				aMap.SetMapIndex(key, field.MapIndex(key))
+			}
+			field.Set(aMap)
+		case reflect.Slice:
+			if field.IsNil() {
+				continue
+			}
+			field.Set(reflect.AppendSlice(reflect.MakeSlice(field.Type(), 0, field.Len()), field))
+		}
+	}
+	return &result
 }
 
 // AddKeyExists adds key exists TestDirective
@@ -88,16 +189,18 @@ func (d *Directive) AddSort(key string) {
 
 // AddKeyExists adds key exists TestDirective
 func (d *Directive) AddKeyExists(key string) {
-	if len(d.KeyExists) == 0 {
-		d.KeyExists = make(map[string]bool)
+	if len(d.KeyExists) == 0 || d.shared.keyExists {
+		d.KeyExists = copyBoolMap(d.KeyExists)
+		d.shared.keyExists = false
 	}
 	d.KeyExists[key] = true
 }
 
 // AddKeyDoesNotExist adds key does exist TestDirective
 func (d *Directive) AddKeyDoesNotExist(key string) {
-	if len(d.KeyDoesNotExist) == 0 {
-		d.KeyDoesNotExist = make(map[string]bool)
+	if len(d.KeyDoesNotExist) == 0 || d.shared.keyDoesNotExist {
+		d.KeyDoesNotExist = copyBoolMap(d.KeyDoesNotExist)
+		d.shared.keyDoesNotExist = false
 	}
 	d.KeyDoesNotExist[key] = true
 }
@@ -112,29 +215,45 @@ func (d *Directive) AddTimeLayout(key, value string) {
 
 // AddElapsedRange adds time layout TestDirective
 func (d *Directive) AddElapsedRange(key, value string) {
-	if len(d.ElaspedRange) == 0 {
-		d.ElaspedRange = make(map[string]string)
+	if len(d.ElaspedRange) == 0 || d.shared.elapsedRange {
+		d.ElaspedRange = copyTextMap(d.ElaspedRange)
+		d.shared.elapsedRange = false
 	}
 	d.ElaspedRange[key] = value
 }
 
 // AddDataType adds data type TestDirective
 func (d *Directive) AddDataType(key, value string) {
-	if len(d.DataType) == 0 {
-		d.DataType = make(map[string]string)
+	if len(d.DataType) == 0 || d.shared.dataType {
+		d.DataType = copyTextMap(d.DataType)
+		d.shared.dataType = false
 	}
 	d.DataType[key] = value
 }
 
+// AddType adds expected data type TestDirective
+func (d *Directive) AddType(key, value string) {
+	if len(d.Types) == 0 {
+		d.Types = make(map[string]string)
+	}
+	d.Types[key] = value
+}
+
 // ExtractDataTypes extracts data from from supplied map
 func (d *Directive) ExtractDataTypes(aMap map[string]interface{}) {
 	for k, v := range aMap {
+		var dataType string
 		if toolbox.IsInt(v) {
-			d.AddDataType(k, "int")
+			dataType = "int"
 		} else if toolbox.IsFloat(v) {
-			d.AddDataType(k, "float")
+			dataType = "float"
 		} else if toolbox.IsBool(v) {
-			d.AddDataType(k, "bool")
+			dataType = "bool"
+		}
+		if dataType != "" {
+			if d.DataType[k] != dataType {
+				d.AddDataType(k, dataType)
+			}
 		} else if toolbox.IsTime(v) {
 			if _, has := d.TimeLayouts[k]; !has {
 				var dateFormat = "yyyy-MM-dd HH:mm:ss.SSSZ"
@@ -162,6 +281,7 @@ func (d *Directive) ApplyKeyCaseInsensitive() {
 	}
 	d.TimeLayouts = d.asCaseInsensitveMap(d.TimeLayouts)
 	d.DataType = d.asCaseInsensitveMap(d.DataType)
+	d.shared.dataType = false
 }
 
 // Add adds by to supplied target
@@ -208,17 +328,80 @@ func (d *Directive) addAssertPath(subpath string, expected interface{}) {
 	})
 }
 
+func (d *Directive) addCount(value interface{}) {
+	if toolbox.IsSlice(value) {
+		for _, item := range toolbox.AsSlice(value) {
+			d.addCount(item)
+		}
+		return
+	}
+	if !toolbox.IsMap(value) {
+		return
+	}
+	aMap := toolbox.AsMap(value)
+	d.Counts = append(d.Counts, &CountMatch{
+		Expected: aMap["match"],
+		Count:    aMap["count"],
+	})
+}
+
+func (d *Directive) addAggregate(key string, expected interface{}) bool {
+	for directive, function := range aggregateDirectives {
+		if strings.HasPrefix(key, directive) {
+			d.Aggregates = append(d.Aggregates, &Aggregate{
+				Function: function,
+				Path:     strings.Replace(key, directive, "", 1),
+				Expected: expected,
+			})
+			return true
+		}
+	}
+	return false
+}
+
 // ExtractDirective extract TestDirective from supplied map
 func (d *Directive) ExtractDirectives(aMap map[string]interface{}) bool {
-	var keyCount = len(aMap)
-	var directiveCount = 0
+	return d.extractEntries(newDirectiveEntries(aMap))
+}
 
-	if len(d.Lengths) == 0 {
-		d.Lengths = make(map[string]int)
+// expectedLengths returns expected lengths and length expressions by key
+func (d *Directive) expectedLengths() map[string]interface{} {
+	var result = make(map[string]interface{}, len(d.Lengths)+len(d.LengthExpressions))
+	for key, length := range d.Lengths {
+		result[key] = length
 	}
-	for k, v := range aMap {
-		if d.IsDirectiveKey(k) {
-			directiveCount++
+	for key, expr := range d.LengthExpressions {
+		result[key] = expr
+	}
+	return result
+}
+
+// removeLength removes expected length or length expression for supplied key, it returns removed value
+func (d *Directive) removeLength(key string) (interface{}, bool) {
+	if length, ok := d.Lengths[key]; ok {
+		delete(d.Lengths, key)
+		return length, true
+	}
+	if expr, ok := d.LengthExpressions[key]; ok {
+		delete(d.LengthExpressions, key)
+		return expr, true
+	}
+	return nil, false
+}
+
+// extractEntries extracts TestDirective from supplied map directive entries, it returns true if all map keys are directives
+func (d *Directive) extractEntries(entries *directiveEntries) bool {
+	for _, entry := range entries.items {
+		k, v := entry.key, entry.value
+		if len(k) == 0 || k[0] != '@' { //only directive values are allowed for non directive keys
+			if text, ok := v.(string); ok && d.IsDirectiveValue(text) {
+				if text == KeyExistsDirective {
+					d.AddKeyExists(k)
+				} else {
+					d.AddKeyDoesNotExist(k)
+				}
+			}
+			continue
 		}
 
 		if k == SwitchByDirective {
@@ -269,6 +452,19 @@ func (d *Directive) ExtractDirectives(aMap map[string]interface{}) bool {
 			continue
 		}
 
+		if k == SomeDirective {
+			d.Some = append(d.Some, asTemplates(v)...)
+			continue
+		}
+		if k == NoneDirective {
+			d.None = append(d.None, asTemplates(v)...)
+			continue
+		}
+		if k == CountDirective {
+			d.addCount(v)
+			continue
+		}
+
 		if strings.HasPrefix(k, AssertPathDirective) {
 			var subPath = strings.Replace(k, AssertPathDirective, "", 1)
 			if subPath != "" {
@@ -289,9 +485,103 @@ func (d *Directive) ExtractDirectives(aMap map[string]interface{}) bool {
 			continue
 		}
 
+		if k == UniqueDirective {
+			d.Unique = toolbox.AsBoolean(v)
+			continue
+		}
+		if k == UniqueByDirective {
+			d.UniqueBy = toStringSlice(v)
+			continue
+		}
+
+		if k == AlignDirective {
+			d.Align = toolbox.AsBoolean(v)
+			continue
+		}
+		if k == SortByDirective {
+			if _, ok := v.(bool); ok {
+				d.SortText = toolbox.AsBoolean(v)
+				continue
+			}
+			d.SortBy = toStringSlice(v)
+			continue
+		}
+		if k == SortedByDirective {
+			d.SortedBy = toStringSlice(v)
+			continue
+		}
+
+		if d.addAggregate(k, v) {
+			continue
+		}
+
+		if strings.HasPrefix(k, ExprDirective) {
+			for _, expression := range asTemplates(v) {
+				d.Exprs = append(d.Exprs, toolbox.AsString(expression))
+			}
+			continue
+		}
+
+		if strings.HasPrefix(k, SchemaDirective) {
+			var key = strings.Replace(k, SchemaDirective, "", 1)
+			if len(d.Schemas) == 0 {
+				d.Schemas = make(map[string]interface{})
+			}
+			d.Schemas[key] = v
+			continue
+		}
+
+		if strings.HasPrefix(k, DecodeDirective) {
+			if len(d.Decodings) == 0 {
+				d.Decodings = make(map[string]string)
+			}
+			d.Decodings[strings.Replace(k, DecodeDirective, "", 1)] = toolbox.AsString(v)
+			continue
+		}
+		if strings.HasPrefix(k, NormalizeDirective) {
+			var key = strings.Replace(k, NormalizeDirective, "", 1)
+			if key == "" {
+				d.Normalize = toStringSlice(v)
+				continue
+			}
+			if len(d.KeyNormalize) == 0 {
+				d.KeyNormalize = make(map[string][]string)
+			}
+			d.KeyNormalize[key] = toStringSlice(v)
+			continue
+		}
+		if strings.HasPrefix(k, OptionalDirective) && k != OptionalDirective {
+			var key = strings.Replace(k, OptionalDirective, "", 1)
+			mergeBoolMap(map[string]bool{key: toolbox.AsBoolean(v)}, &d.Optional)
+			continue
+		}
+		if strings.HasPrefix(k, NullableDirective) && k != NullableDirective {
+			var key = strings.Replace(k, NullableDirective, "", 1)
+			mergeBoolMap(map[string]bool{key: toolbox.AsBoolean(v)}, &d.Nullable)
+			continue
+		}
+
+		if strings.HasPrefix(k, KeyCountDirective) {
+			if len(d.KeyCounts) == 0 {
+				d.KeyCounts = make(map[string]interface{})
+			}
+			d.KeyCounts[strings.Replace(k, KeyCountDirective, "", 1)] = v
+			continue
+		}
+
 		if strings.HasPrefix(k, LengthDirective) {
 			var key = strings.Replace(k, LengthDirective, "", 1)
-			d.Lengths[key] = toolbox.AsInt(v)
+			if length, err := strconv.Atoi(strings.TrimSpace(toolbox.AsString(v))); err == nil {
+				if len(d.Lengths) == 0 {
+					d.Lengths = make(map[string]int)
+				}
+				d.Lengths[key] = length
+				continue
+			}
+			if len(d.LengthExpressions) == 0 {
+				d.LengthExpressions = make(map[string]interface{})
+			}
+			d.LengthExpressions[key] = v
 			continue
 		} else if strings.HasPrefix(k, KeyExistsDirective) {
 			var key = strings.Replace(k, KeyExistsDirective, "", 1)
@@ -346,13 +636,26 @@ func (d *Directive) ExtractDirectives(aMap map[string]interface{}) bool {
 				}
 				continue
 			}
+			if strings.HasPrefix(k, CaptureDirective) {
+				var key = strings.Replace(k, CaptureDirective, "", 1)
+				if len(d.Captures) == 0 {
+					d.Captures = make(map[string]string)
+				}
+				d.Captures[key] = text
+				continue
+			}
+			if strings.HasPrefix(k, TypeDirective) {
+				var key = strings.Replace(k, TypeDirective, "", 1)
+				d.AddType(key, text)
+				continue
+			}
 			if strings.HasPrefix(k, CastDataTypeDirective) {
 				var key = strings.Replace(k, CastDataTypeDirective, "", 1)
 				d.AddDataType(key, text)
 			}
 		}
 	}
-	return keyCount > 0 && keyCount == directiveCount
+	return entries.size > 0 && entries.size == entries.directiveCount
 }
 
 // Apply applies TestDirective to supplied map
@@ -398,6 +701,19 @@ func (d *Directive) applyTimeFormat(aMap map[string]interface{}) error {
 	return nil
 }
 
+// isCastedType returns true if value already has supplied cast data type
+func isCastedType(value interface{}, dataType string) bool {
+	switch value.(type) {
+	case int:
+		return dataType == "int"
+	case float64:
+		return dataType == "float"
+	case bool:
+		return dataType == "bool"
+	}
+	return false
+}
+
 func (d *Directive) castData(aMap map[string]interface{}) error {
 	if len(d.DataType) == 0 {
 		return nil
@@ -407,7 +723,7 @@ func (d *Directive) castData(aMap map[string]interface{}) error {
 		var casted interface{}
 
 		val, ok := aMap[key]
-		if !ok || val == nil || getPredicate(val) != nil || toolbox.IsFunc(val) {
+		if !ok || val == nil || isCastedType(val, dataType) || getPredicate(val) != nil || toolbox.IsFunc(val) {
 			continue
 		}
 
@@ -416,7 +732,7 @@ func (d *Directive) castData(aMap map[string]interface{}) error {
 			continue
 		}
 
-		if d.IsDirectiveValue(toolbox.AsString(val)) {
+		if d.IsDirectiveValue(textVal) {
 			continue
 		}
 
@@ -449,11 +765,19 @@ func (d *Directive) castData(aMap map[string]interface{}) error {
 
 // IsDirectiveKey returns true if key is TestDirective
 func (d *Directive) IsDirectiveKey(key string) bool {
-	return strings.HasPrefix(key, "@") && strings.Count(key, "@") > 1
+	return isDirectiveKey(key)
 }
 
 // IsDirectiveKey returns true if value is TestDirective
 func (d *Directive) IsDirectiveValue(value string) bool {
+	return isDirectiveValue(value)
+}
+
+func isDirectiveKey(key string) bool {
+	return strings.HasPrefix(key, "@") && strings.Count(key, "@") > 1
+}
+
+func isDirectiveValue(value string) bool {
 	return value == KeyExistsDirective ||
 		value == KeyDoesNotExistsDirective
 }
@@ -472,7 +796,6 @@ func NewDirective(path DataPath) *Directive {
 		DataPath:         path,
 		KeyCaseSensitive: true,
 		CaseSensitive:    true,
-		AssertPaths:      make([]*AssertPath, 0),
 	}
 	if dataPath != nil {
 		dataPath.directive = result
@@ -558,6 +881,109 @@ func (r TestDirective) Cast(field, dataType string) TestDirective {
 	return r
 }
 
+func (r TestDirective) Some(expected interface{}) TestDirective {
+	r[SomeDirective] = expected
+	return r
+}
+
+func (r TestDirective) None(expected interface{}) TestDirective {
+	r[NoneDirective] = expected
+	return r
+}
+
+func (r TestDirective) Count(expected, count interface{}) TestDirective {
+	r[CountDirective] = map[string]interface{}{
+		"match": expected,
+		"count": count,
+	}
+	return r
+}
+
+func (r TestDirective) Type(field, dataType string) TestDirective {
+	r[TypeDirective+field] = dataType
+	return r
+}
+
+func (r TestDirective) Schema(field string, schema interface{}) TestDirective {
+	r[SchemaDirective+field] = schema
+	return r
+}
+
+func (r TestDirective) Capture(field, name string) TestDirective {
+	r[CaptureDirective+field] = name
+	return r
+}
+
+func (r TestDirective) Expr(expression string) TestDirective {
+	expressions, _ := r[ExprDirective].([]string)
+	r[ExprDirective] = append(expressions, expression)
+	return r
+}
+
+func (r TestDirective) Sum(field string, expected interface{}) TestDirective {
+	r[SumDirective+field] = expected
+	return r
+}
+
+func (r TestDirective) Unique() TestDirective {
+	r[UniqueDirective] = true
+	return r
+}
+
+func (r TestDirective) UniqueBy(fields ...string) TestDirective {
+	r[UniqueByDirective] = strings.Join(fields, ",")
+	return r
+}
+
+func (r TestDirective) SortedBy(keys ...string) TestDirective {
+	r[SortedByDirective] = strings.Join(keys, ",")
+	return r
+}
+
+func (r TestDirective) SortBy(keys ...string) TestDirective {
+	if len(keys) == 0 {
+		r[SortByDirective] = true
+		return r
+	}
+	r[SortByDirective] = strings.Join(keys, ",")
+	return r
+}
+
+func (r TestDirective) When(expression string, entries map[string]interface{}) TestDirective {
+	r[WhenDirective+expression] = entries
+	return r
+}
+
+func (r TestDirective) KeyCount(pattern string, count interface{}) TestDirective {
+	r[KeyCountDirective+pattern] = count
+	return r
+}
+
+func (r TestDirective) Optional(key string) TestDirective {
+	r[OptionalDirective+key] = true
+	return r
+}
+
+func (r TestDirective) Nullable(key string) TestDirective {
+	r[NullableDirective+key] = true
+	return r
+}
+
+func (r TestDirective) Normalize(key string, normalizations ...string) TestDirective {
+	r[NormalizeDirective+key] = strings.Join(normalizations, ",")
+	return r
+}
+
+func (r TestDirective) Align() TestDirective {
+	r[AlignDirective] = true
+	return r
+}
+
+func (r TestDirective) Decode(key, decoding string) TestDirective {
+	r[DecodeDirective+key] = decoding
+	return r
+}
+
 func (r TestDirective) SortText() TestDirective {
 	r[SortTextDirective] = true
 	return r
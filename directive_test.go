@@ -193,3 +193,15 @@ func TestAssertPath_Directive(t *testing.T) {
 	}
 
 }
+
+func TestDirective_ExtractLengths(t *testing.T) {
+	directive := &Directive{}
+	directive.ExtractDirectives(map[string]interface{}{
+		LengthDirective + "items": 3,
+		LengthDirective + "tags":  "2",
+		LengthDirective + "name":  "/[1..10]/",
+		LengthDirective:           ">0",
+	})
+	assert.EqualValues(t, map[string]int{"items": 3, "tags": 2}, directive.Lengths)
+	assert.EqualValues(t, map[string]interface{}{"name": "/[1..10]/", "": ">0"}, directive.LengthExpressions)
+}
@@ -0,0 +1,513 @@
+package assertly
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// expression represents a parsed expression evaluated against actual data structure
+type expression struct {
+	text string
+	root exprNode
+}
+
+// exprState represents expression evaluation state
+type exprState struct {
+	actual     interface{}
+	timeLayout string
+	operands   map[string]interface{}
+}
+
+type exprNode interface {
+	evaluate(state *exprState) (interface{}, error)
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+type identifierNode struct {
+	path string
+}
+
+type unaryNode struct {
+	operator string
+	operand  exprNode
+}
+
+type binaryNode struct {
+	operator    string
+	left, right exprNode
+}
+
+type callNode struct {
+	name string
+	text string
+	args []exprNode
+}
+
+func (n *literalNode) evaluate(state *exprState) (interface{}, error) {
+	return n.value, nil
+}
+
+func (n *identifierNode) evaluate(state *exprState) (interface{}, error) {
+	value, _ := selectValue(state.actual, n.path)
+	state.operands[n.path] = value
+	return value, nil
+}
+
+func (n *unaryNode) evaluate(state *exprState) (interface{}, error) {
+	value, err := n.operand.evaluate(state)
+	if err != nil {
+		return nil, err
+	}
+	if n.operator == "!" {
+		return !isTruthy(value), nil
+	}
+	number, err := asNumber(value)
+	if err != nil {
+		return nil, err
+	}
+	return -number, nil
+}
+
+func (n *binaryNode) evaluate(state *exprState) (interface{}, error) {
+	left, err := n.left.evaluate(state)
+	if err != nil {
+		return nil, err
+	}
+	switch n.operator {
+	case "&&":
+		if !isTruthy(left) {
+			return false, nil
+		}
+		right, err := n.right.evaluate(state)
+		return isTruthy(right), err
+	case "||":
+		if isTruthy(left) {
+			return true, nil
+		}
+		right, err := n.right.evaluate(state)
+		return isTruthy(right), err
+	}
+	right, err := n.right.evaluate(state)
+	if err != nil {
+		return nil, err
+	}
+	switch n.operator {
+	case "==", "!=", ">", ">=", "<", "<=":
+		return compareOperands(n.operator, left, right, state.timeLayout)
+	}
+	leftNumber, err := asNumber(left)
+	if err != nil {
+		return nil, err
+	}
+	rightNumber, err := asNumber(right)
+	if err != nil {
+		return nil, err
+	}
+	switch n.operator {
+	case "+":
+		return leftNumber + rightNumber, nil
+	case "-":
+		return leftNumber - rightNumber, nil
+	case "*":
+		return leftNumber * rightNumber, nil
+	case "/":
+		if rightNumber == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return leftNumber / rightNumber, nil
+	case "%":
+		if rightNumber == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return math.Mod(leftNumber, rightNumber), nil
+	}
+	return nil, fmt.Errorf("unsupported operator: %v", n.operator)
+}
+
+func (n *callNode) evaluate(state *exprState) (interface{}, error) {
+	var values = make([]interface{}, 0)
+	//only function result is reported as operand
+	argState := &exprState{actual: state.actual, timeLayout: state.timeLayout, operands: make(map[string]interface{})}
+	for _, arg := range n.args {
+		value, err := arg.evaluate(argState)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil && toolbox.IsSlice(value) {
+			values = append(values, toolbox.AsSlice(value)...)
+			continue
+		}
+		values = append(values, value)
+	}
+	result, err := aggregateValues(n.name, values, state.timeLayout)
+	if err != nil {
+		return nil, err
+	}
+	state.operands[n.text] = result
+	return result, nil
+}
+
+// selectValue returns value for supplied path, slices along the path are projected into a slice of values
+func selectValue(source interface{}, path string) (interface{}, bool) {
+	if source == nil {
+		return nil, false
+	}
+	if toolbox.IsMap(source) {
+		aMap := data.Map(toolbox.AsMap(source))
+		if value, ok := aMap.GetValue(path); ok {
+			return value, true
+		}
+		index := strings.Index(path, ".")
+		if index == -1 {
+			return nil, false
+		}
+		value, ok := aMap.GetValue(path[:index])
+		if !ok {
+			return nil, false
+		}
+		return selectValue(value, path[index+1:])
+	}
+	if toolbox.IsSlice(source) {
+		var result = make([]interface{}, 0)
+		for _, item := range toolbox.AsSlice(source) {
+			if value, ok := selectValue(item, path); ok {
+				result = append(result, value)
+			}
+		}
+		return result, true
+	}
+	return nil, false
+}
+
+func isTruthy(value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	if boolValue, ok := value.(bool); ok {
+		return boolValue
+	}
+	return toolbox.AsBoolean(value)
+}
+
+func asNumber(value interface{}) (float64, error) {
+	if value == nil {
+		return 0, fmt.Errorf("operand was nil")
+	}
+	number, err := toolbox.ToFloat(value)
+	if err != nil {
+		return 0, fmt.Errorf("operand %v was not numeric", value)
+	}
+	return number, nil
+}
+
+func asOperandTime(value interface{}, timeLayout string) *time.Time {
+	if toolbox.IsTime(value) {
+		result, _ := toolbox.ToTime(value, timeLayout)
+		return result
+	}
+	text, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	for _, layout := range []string{time.RFC3339Nano, timeLayout} {
+		if result, err := time.Parse(layout, text); err == nil {
+			return &result
+		}
+	}
+	return nil
+}
+
+func compareOperands(operator string, left, right interface{}, timeLayout string) (bool, error) {
+	if left == nil || right == nil {
+		switch operator {
+		case "==":
+			return left == right, nil
+		case "!=":
+			return left != right, nil
+		}
+		return false, nil
+	}
+	comparison := compareValues(left, right, timeLayout)
+	switch operator {
+	case "==":
+		return comparison == 0, nil
+	case "!=":
+		return comparison != 0, nil
+	case ">":
+		return comparison > 0, nil
+	case ">=":
+		return comparison >= 0, nil
+	case "<":
+		return comparison < 0, nil
+	case "<=":
+		return comparison <= 0, nil
+	}
+	return false, fmt.Errorf("unsupported operator: %v", operator)
+}
+
+// compareValues compares numeric values as numbers, time values as time, others as text
+func compareValues(left, right interface{}, timeLayout string) int {
+	leftNumber, leftErr := toolbox.ToFloat(left)
+	rightNumber, rightErr := toolbox.ToFloat(right)
+	if leftErr == nil && rightErr == nil && !toolbox.IsBool(left) && !toolbox.IsBool(right) {
+		return compareFloats(leftNumber, rightNumber)
+	}
+	if leftTime, rightTime := asOperandTime(left, timeLayout), asOperandTime(right, timeLayout); leftTime != nil && rightTime != nil {
+		if leftTime.Before(*rightTime) {
+			return -1
+		} else if leftTime.After(*rightTime) {
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(toolbox.AsString(left), toolbox.AsString(right))
+}
+
+func compareFloats(left, right float64) int {
+	if left < right {
+		return -1
+	} else if left > right {
+		return 1
+	}
+	return 0
+}
+
+// Evaluate evaluates expression against supplied actual value, it returns expression result and evaluated operands
+func (e *expression) Evaluate(actual interface{}, timeLayout string) (interface{}, map[string]interface{}, error) {
+	state := &exprState{actual: actual, timeLayout: timeLayout, operands: make(map[string]interface{})}
+	result, err := e.root.evaluate(state)
+	if err != nil {
+		return nil, state.operands, fmt.Errorf("failed to evaluate %v, %v", e.text, err)
+	}
+	return result, state.operands, nil
+}
+
+type exprParser struct {
+	text   string
+	tokens []string
+	pos    int
+}
+
+var binaryPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	">": 4, ">=": 4, "<": 4, "<=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+var keywordOperators = map[string]string{
+	"and": "&&",
+	"or":  "||",
+	"not": "!",
+}
+
+func tokenizeExpression(text string) ([]string, error) {
+	var result = make([]string, 0)
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at %v", i)
+			}
+			result = append(result, string(runes[i:end+1]))
+			i = end + 1
+		case unicode.IsDigit(r):
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.') {
+				end++
+			}
+			result = append(result, string(runes[i:end]))
+			i = end
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			end := i
+			for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || strings.ContainsRune("_$.[]", runes[end])) {
+				end++
+			}
+			result = append(result, string(runes[i:end]))
+			i = end
+		default:
+			if i+1 < len(runes) {
+				if pair := string(runes[i : i+2]); pair == "==" || pair == "!=" || pair == ">=" || pair == "<=" || pair == "&&" || pair == "||" {
+					result = append(result, pair)
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("()+-*/%<>!,", r) {
+				return nil, fmt.Errorf("unexpected character '%c' at %v", r, i)
+			}
+			result = append(result, string(r))
+			i++
+		}
+	}
+	return result, nil
+}
+
+func (p *exprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		token := p.tokens[p.pos]
+		if operator, ok := keywordOperators[strings.ToLower(token)]; ok {
+			return operator
+		}
+		return token
+	}
+	return ""
+}
+
+func (p *exprParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *exprParser) expect(token string) error {
+	if actual := p.next(); actual != token {
+		return fmt.Errorf("expected '%v' but had '%v'", token, actual)
+	}
+	return nil
+}
+
+func (p *exprParser) parseBinary(minPrecedence int) (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		operator := p.peek()
+		precedence, ok := binaryPrecedence[operator]
+		if !ok || precedence < minPrecedence {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseBinary(precedence + 1)
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{operator: operator, left: left, right: right}
+	}
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if operator := p.peek(); operator == "!" || operator == "-" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{operator: operator, operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	start := p.pos
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case token == "(":
+		node, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+		return node, p.expect(")")
+	case strings.HasPrefix(token, "'") || strings.HasPrefix(token, "\""):
+		return &literalNode{value: token[1 : len(token)-1]}, nil
+	case unicode.IsDigit([]rune(token)[0]):
+		number, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %v", token)
+		}
+		return &literalNode{value: number}, nil
+	}
+	switch strings.ToLower(token) {
+	case "true":
+		return &literalNode{value: true}, nil
+	case "false":
+		return &literalNode{value: false}, nil
+	case "null", "nil":
+		return &literalNode{value: nil}, nil
+	}
+	if !(unicode.IsLetter([]rune(token)[0]) || token[0] == '_' || token[0] == '$') {
+		return nil, fmt.Errorf("unexpected token: '%v'", token)
+	}
+	if p.peek() != "(" {
+		return &identifierNode{path: token}, nil
+	}
+	p.next()
+	var call = &callNode{name: strings.ToLower(token)}
+	for p.peek() != ")" {
+		arg, err := p.parseBinary(1)
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+		if p.peek() == "," {
+			p.next()
+		}
+	}
+	p.next()
+	call.text = strings.Join(p.tokens[start:p.pos], "")
+	return call, nil
+}
+
+// parseExpression parses supplied expression text
+func parseExpression(text string) (*expression, error) {
+	tokens, err := tokenizeExpression(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %v, %v", text, err)
+	}
+	parser := &exprParser{text: text, tokens: tokens}
+	root, err := parser.parseBinary(1)
+	if err == nil && parser.pos < len(tokens) {
+		err = fmt.Errorf("unexpected token: '%v'", tokens[parser.pos])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression %v, %v", text, err)
+	}
+	return &expression{text: text, root: root}, nil
+}
+
+func assertExpressions(expressions []string, actual interface{}, path DataPath, context *Context, validation *Validation) error {
+	timeLayout := path.Match(context).DefaultTimeLayout()
+	for _, text := range expressions {
+		expr, err := context.expression(text)
+		if err != nil {
+			return fmt.Errorf("%v, path: %v", err, path.Path())
+		}
+		result, operands, err := expr.Evaluate(actual, timeLayout)
+		if err != nil { //missing or incompatible operands are reported as violation, only invalid expression is an error
+			validation.AddFailure(newFailure(path.Source(), path.Path(), PredicateViolation, text, operands, err))
+			continue
+		}
+		passed, ok := result.(bool)
+		if !ok {
+			return fmt.Errorf("expression %v should evaluate to bool but had %T(%v), path: %v", text, result, result, path.Path())
+		}
+		if passed {
+			validation.PassedCount++
+			continue
+		}
+		validation.AddFailure(newFailure(path.Source(), path.Path(), PredicateViolation, text, operands))
+	}
+	return nil
+}
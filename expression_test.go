@@ -0,0 +1,55 @@
+package assertly
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExpression_Evaluate(t *testing.T) {
+	var actual = map[string]interface{}{
+		"a":     3,
+		"b":     "4",
+		"name":  "abc",
+		"flag":  true,
+		"items": []interface{}{map[string]interface{}{"v": 1}, map[string]interface{}{"v": 2}, map[string]interface{}{"v": 2}},
+	}
+	var useCases = []struct {
+		expr     string
+		expected interface{}
+		hasError bool
+	}{
+		{expr: "a + b * 2", expected: 11.0},
+		{expr: "(a + b) * 2", expected: 14.0},
+		{expr: "-a + 10 % 4", expected: -1.0},
+		{expr: "a < b && name == 'abc'", expected: true},
+		{expr: "not flag or a >= 3", expected: true},
+		{expr: "missing == null", expected: true},
+		{expr: "sum(items.v) / count(items)", expected: 5.0 / 3.0},
+		{expr: "distinct(items.v)", expected: 2},
+		{expr: "max(items.v, a)", expected: 3.0},
+		{expr: "abs(a - 10)", expected: 7.0},
+		{expr: "name + 1", hasError: true},
+		{expr: "a / 0", hasError: true},
+		{expr: "unknown(a)", hasError: true},
+	}
+	for _, useCase := range useCases {
+		expr, err := parseExpression(useCase.expr)
+		if !assert.Nil(t, err, useCase.expr) {
+			continue
+		}
+		result, _, err := expr.Evaluate(actual, "")
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.expr)
+			continue
+		}
+		assert.Nil(t, err, useCase.expr)
+		assert.EqualValues(t, useCase.expected, result, useCase.expr)
+	}
+}
+
+func TestParseExpression_Error(t *testing.T) {
+	for _, text := range []string{"a >", "(a", "a b", "'abc", "a # b"} {
+		_, err := parseExpression(text)
+		assert.NotNil(t, err, text)
+	}
+}
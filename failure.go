@@ -56,8 +56,9 @@ func (f *Failure) LeafKey() string {
 	return string(f.Path[leafIndex+1:])
 }
 
+// removeDirectives returns a copy of the map without directive keys
 func removeDirectives(aMap map[string]interface{}) map[string]interface{} {
-	var result = make(map[string]interface{})
+	var result = make(map[string]interface{}, len(aMap))
 	for k, v := range aMap {
 		if strings.HasPrefix(k, "@") {
 			continue
@@ -69,15 +70,15 @@ func removeDirectives(aMap map[string]interface{}) map[string]interface{} {
 
 // NewFailure creates a new failure
 func NewFailure(source, path string, reason string, expected, actual interface{}, args ...interface{}) *Failure {
+	var result = newFailure(source, path, reason, expected, actual, args...)
+	result.render()
+	return result
+}
 
-	if expected != nil && toolbox.IsMap(expected) {
-		expected = removeDirectives(toolbox.AsMap(expected))
-	}
-	if actual != nil && toolbox.IsMap(actual) {
-		actual = removeDirectives(toolbox.AsMap(actual))
-	}
-
-	var result = &Failure{
+// newFailure creates a new failure that is rendered once added to a validation,
+// so that failures of candidate matching validations that are never reported do not copy maps nor format messages
+func newFailure(source, path string, reason string, expected, actual interface{}, args ...interface{}) *Failure {
+	return &Failure{
 		Source:   source,
 		Path:     path,
 		Reason:   reason,
@@ -85,8 +86,20 @@ func NewFailure(source, path string, reason string, expected, actual interface{}
 		Actual:   actual,
 		Args:     args,
 	}
-	result.Message = FormatMessage(result)
-	return result
+}
+
+// render replaces expected and actual maps with copies without directive keys and formats the message, rendered failure is left unchanged
+func (f *Failure) render() {
+	if f.Message != "" {
+		return
+	}
+	if f.Expected != nil && toolbox.IsMap(f.Expected) {
+		f.Expected = removeDirectives(toolbox.AsMap(f.Expected))
+	}
+	if f.Actual != nil && toolbox.IsMap(f.Actual) {
+		f.Actual = removeDirectives(toolbox.AsMap(f.Actual))
+	}
+	f.Message = FormatMessage(f)
 }
 
 func FormatMessage(failure *Failure) string {
@@ -94,7 +107,14 @@ func FormatMessage(failure *Failure) string {
 	case MissingEntryViolation:
 		return fmt.Sprintf("entry for %v was missing, expected: %v, actual keys: %v", failure.Args[0], failure.Expected, failure.Actual)
 	case MissingItemViolation:
+		if len(failure.Args) > 0 {
+			return fmt.Sprintf("expected item[%v] was missing: %v", failure.Args[0], failure.Expected)
+		}
 		return fmt.Sprintf("item was missing, expected: %v, actual:  %v", failure.Expected, failure.Actual)
+	case DecodeViolation:
+		return fmt.Sprintf("actual '%v' was not %v encoded: %v", failure.Actual, failure.Expected, failure.Args[0])
+	case UnexpectedItemViolation:
+		return fmt.Sprintf("actual item was not expected: %v", failure.Actual)
 	case ItemMismatchViolation:
 		return fmt.Sprintf("item was mismatched, key1: %v, key2: %v", failure.Expected, failure.Actual)
 	case IncompatibleDataTypeViolation:
@@ -104,10 +124,18 @@ func FormatMessage(failure *Failure) string {
 	case KeyDoesNotExistViolation:
 		return fmt.Sprintf("'%v' should not exists", failure.Expected)
 	case EqualViolation:
+		if len(failure.Args) > 0 {
+			if diff, ok := failure.Args[0].(*TextDiff); ok && diff != nil {
+				return fmt.Sprintf("actual text was not equal expected at line %v, column %v:\n%v", diff.Line, diff.Column, diff.Unified())
+			}
+		}
 		return fmt.Sprintf("actual(%T): '%v' was not equal (%T) '%v'", failure.Actual, failure.Actual, failure.Expected, failure.Expected)
 	case NotEqualViolation:
 		return fmt.Sprintf("actual(%T): '%v' was equal (%T) '%v'", failure.Actual, failure.Actual, failure.Expected, failure.Expected)
 	case LengthViolation:
+		if toolbox.IsString(failure.Expected) {
+			return fmt.Sprintf("actual length %v did not satisfy: %v", failure.Actual, failure.Expected)
+		}
 		return fmt.Sprintf("actual length %v  was not equal: %v", failure.Actual, failure.Expected)
 	case MissingCaseViolation:
 		switchBy := failure.Args[0].([]string)
@@ -128,9 +156,49 @@ func FormatMessage(failure *Failure) string {
 	case DoesNotContainViolation:
 		return fmt.Sprintf("actual '%v' should not not contain: '%v'", failure.Actual, failure.Expected)
 	case PredicateViolation:
+		if len(failure.Args) > 0 {
+			return fmt.Sprintf("actual '%v' should pass predicate: '%v', %v", failure.Actual, failure.Expected, failure.Args[0])
+		}
 		return fmt.Sprintf("actual '%v' should pass predicate: '%v'", failure.Actual, failure.Expected)
 	case ElapseRangeViolation:
 		return fmt.Sprintf("actual '%v' should be within: '%v'", failure.Actual, failure.Expected)
+	case TypeViolation:
+		if failure.Args[0] == "missing" {
+			return fmt.Sprintf("entry was missing, expected data type: %v", failure.Expected)
+		}
+		return fmt.Sprintf("actual '%v' (%v) was not of data type: %v", failure.Actual, failure.Args[0], failure.Expected)
+	case SchemaViolation:
+		return fmt.Sprintf("actual '%v' did not validate with schema %v: %v", failure.Actual, failure.Expected, failure.Args[0])
+	case SomeViolation:
+		if failure.Args[1].(int) == -1 {
+			return fmt.Sprintf("none of %v items matched: %v", failure.Args[0], failure.Expected)
+		}
+		return fmt.Sprintf("none of %v items matched: %v, closest item[%v]: %v, mismatches: %v", failure.Args[0], failure.Expected, failure.Args[1], failure.Actual, formatMismatches(failure.Args[2].([]*Failure)))
+	case NoneViolation:
+		return fmt.Sprintf("item: %v should not match: %v", failure.Actual, failure.Expected)
+	case CountViolation:
+		message := fmt.Sprintf("actual matching items count %v was not: %v, expected: %v, matched items: %v", failure.Actual, failure.Expected, failure.Args[0], failure.Args[1])
+		if failure.Args[2].(int) != -1 {
+			message += fmt.Sprintf(", closest not matched item[%v] mismatches: %v", failure.Args[2], formatMismatches(failure.Args[3].([]*Failure)))
+		}
+		return message
+	case UniqueViolation:
+		return fmt.Sprintf("duplicate %v: '%v' at item[%v] and item[%v]", failure.Expected, failure.Actual, failure.Args[0], failure.Args[1])
+	case SortedViolation:
+		return fmt.Sprintf("%v was out of order after %v at %v, expected order: %v", failure.Actual, failure.Args[1], failure.Args[0], failure.Expected)
+	case OneOfViolation, AnyOfViolation:
+		candidates := toolbox.AsSlice(failure.Expected)
+		if matched := failure.Args[0].([]int); len(matched) > 1 {
+			return fmt.Sprintf("actual '%v' matched %v of %v candidates: %v, but expected exactly one", failure.Actual, len(matched), len(candidates), matched)
+		}
+		if failure.Args[1].(int) == -1 {
+			return fmt.Sprintf("actual '%v' did not match any of %v candidates", failure.Actual, len(candidates))
+		}
+		return fmt.Sprintf("actual '%v' did not match any of %v candidates, closest candidate[%v] had %v failure(s)", failure.Actual, len(candidates), failure.Args[1], failure.Args[2])
+	case KeyCountViolation:
+		return fmt.Sprintf("actual matching keys count %v was not: %v, matched keys: %v", failure.Actual, failure.Expected, failure.Args[0])
+	case IndexKeyViolation:
+		return fmt.Sprintf("duplicate index key %v: '%v' at item[%v] and item[%v]", failure.Expected, failure.Actual, failure.Args[0], failure.Args[1])
 
 	}
 	return failure.Reason
@@ -54,3 +54,21 @@ func TestFailure_MergeFrom(t *testing.T) {
 	assert.EqualValues(t, 2, target.FailedCount)
 	assert.EqualValues(t, 2, len(target.Failures))
 }
+
+func TestFailure_Render(t *testing.T) {
+	expected := map[string]interface{}{"@indexBy@": "id", "id": 1}
+	actual := map[string]interface{}{"id": 2}
+
+	trial := newTrialValidation()
+	trial.AddFailure(newFailure("", "[/]:[0]", EqualViolation, expected, actual))
+	failure := trial.Failures[0]
+	assert.EqualValues(t, "", failure.Message, "candidate matching failure should not be rendered")
+
+	target := NewValidation()
+	target.MergeFrom(trial)
+	assert.NotEqual(t, "", failure.Message)
+	assert.EqualValues(t, map[string]interface{}{"id": 1}, failure.Expected)
+
+	actual["id"] = 3
+	assert.EqualValues(t, map[string]interface{}{"id": 2}, failure.Actual, "rendered failure should not alias actual map")
+}
@@ -1,6 +1,7 @@
 package assertly
 
 import (
+	"fmt"
 	"github.com/viant/toolbox"
 	"github.com/viant/toolbox/data"
 	"strings"
@@ -72,6 +73,54 @@ func mergeBoolMap(source map[string]bool, target *map[string]bool) {
 	}
 }
 
+// shareTextMap merges source into target, an empty target shares source until the first write, it returns target shared flag
+func shareTextMap(source map[string]string, target *map[string]string, shared bool) bool {
+	if len(source) == 0 {
+		return shared
+	}
+	if len(*target) == 0 {
+		*target = source
+		return true
+	}
+	if shared {
+		*target = copyTextMap(*target)
+	}
+	mergeTextMap(source, target)
+	return false
+}
+
+// shareBoolMap merges source into target, an empty target shares source until the first write, it returns target shared flag
+func shareBoolMap(source map[string]bool, target *map[string]bool, shared bool) bool {
+	if len(source) == 0 {
+		return shared
+	}
+	if len(*target) == 0 {
+		*target = source
+		return true
+	}
+	if shared {
+		*target = copyBoolMap(*target)
+	}
+	mergeBoolMap(source, target)
+	return false
+}
+
+func copyTextMap(source map[string]string) map[string]string {
+	var result = make(map[string]string, len(source)+1)
+	for k, v := range source {
+		result[k] = v
+	}
+	return result
+}
+
+func copyBoolMap(source map[string]bool) map[string]bool {
+	var result = make(map[string]bool, len(source)+1)
+	for k, v := range source {
+		result[k] = v
+	}
+	return result
+}
+
 func keysValue(aMap data.Map, keys ...string) string {
 	var result = ""
 	for _, key := range keys {
@@ -134,3 +183,85 @@ func isIndexable(source map[string]interface{}) bool {
 	}
 	return false
 }
+
+func asTemplates(source interface{}) []interface{} {
+	if source != nil && toolbox.IsSlice(source) {
+		return toolbox.AsSlice(source)
+	}
+	return []interface{}{source}
+}
+
+// cloneValue returns a deep copy of supplied maps and slices, other values are returned as is
+func cloneValue(source interface{}) interface{} {
+	if source == nil {
+		return nil
+	}
+	if toolbox.IsMap(source) {
+		var result = make(map[string]interface{})
+		for k, v := range toolbox.AsMap(source) {
+			result[k] = cloneValue(v)
+		}
+		return result
+	}
+	if toolbox.IsSlice(source) {
+		aSlice := toolbox.AsSlice(source)
+		var result = make([]interface{}, len(aSlice))
+		for i, item := range aSlice {
+			result[i] = cloneValue(item)
+		}
+		return result
+	}
+	return source
+}
+
+// matchCount returns true if actual count satisfies expected expression: N, !N, >N, >=N, <N, <=N or /[min..max]/
+func matchCount(expected interface{}, actual int) (bool, error) {
+	if !toolbox.IsString(expected) {
+		count, err := toolbox.ToInt(expected)
+		if err != nil {
+			return false, fmt.Errorf("invalid count: %v, %v", expected, err)
+		}
+		return actual == count, nil
+	}
+	expr := strings.TrimSpace(toolbox.AsString(expected))
+	if strings.HasPrefix(expr, "/[") && strings.HasSuffix(expr, "]/") {
+		bounds := strings.Split(expr[2:len(expr)-2], "..")
+		if len(bounds) != 2 {
+			return false, fmt.Errorf("invalid count range format, expected /[min..max]/, but had: %v", expr)
+		}
+		min, err := toolbox.ToInt(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return false, fmt.Errorf("invalid count range min: %v, %v", expr, err)
+		}
+		max, err := toolbox.ToInt(strings.TrimSpace(bounds[1]))
+		if err != nil {
+			return false, fmt.Errorf("invalid count range max: %v, %v", expr, err)
+		}
+		return actual >= min && actual <= max, nil
+	}
+	var operator = ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "!", "="} {
+		if strings.HasPrefix(expr, candidate) {
+			operator = candidate
+			expr = strings.TrimSpace(expr[len(candidate):])
+			break
+		}
+	}
+	count, err := toolbox.ToInt(expr)
+	if err != nil {
+		return false, fmt.Errorf("invalid count expression: %v, %v", expected, err)
+	}
+	switch operator {
+	case ">=":
+		return actual >= count, nil
+	case "<=":
+		return actual <= count, nil
+	case ">":
+		return actual > count, nil
+	case "<":
+		return actual < count, nil
+	case "!":
+		return actual != count, nil
+	}
+	return actual == count, nil
+}
@@ -1,6 +1,7 @@
 package assertly
 
 import (
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/toolbox"
 	"reflect"
@@ -193,3 +194,33 @@ func Test_ToStringSlice(t *testing.T) {
 		assert.EqualValues(t, []string{"1"}, aSlice)
 	}
 }
+
+func Test_MatchCount(t *testing.T) {
+	var useCases = []struct {
+		expected interface{}
+		actual   int
+		matched  bool
+		hasError bool
+	}{
+		{expected: 3, actual: 3, matched: true},
+		{expected: "3", actual: 2},
+		{expected: ">2", actual: 3, matched: true},
+		{expected: ">=3", actual: 3, matched: true},
+		{expected: "<3", actual: 3},
+		{expected: "<= 3", actual: 3, matched: true},
+		{expected: "!0", actual: 0},
+		{expected: "/[1..10]/", actual: 10, matched: true},
+		{expected: "/[1..10]/", actual: 0},
+		{expected: "/[1..]/", actual: 0, hasError: true},
+		{expected: ">a", actual: 0, hasError: true},
+	}
+	for _, useCase := range useCases {
+		matched, err := matchCount(useCase.expected, useCase.actual)
+		if useCase.hasError {
+			assert.NotNil(t, err, fmt.Sprintf("%v", useCase.expected))
+			continue
+		}
+		assert.Nil(t, err)
+		assert.EqualValues(t, useCase.matched, matched, fmt.Sprintf("%v %v", useCase.expected, useCase.actual))
+	}
+}
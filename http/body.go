@@ -0,0 +1,110 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/viant/assertly"
+)
+
+// decodeBody decodes body by content type: JSON, form, XML, or text for other content types
+func decodeBody(contentType string, body []byte) (interface{}, error) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return string(body), nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var result interface{}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON body: %v", err)
+		}
+		return result, nil
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode form body: %v", err)
+		}
+		return assertly.ValuesMap(values), nil
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		result, err := decodeXML(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode XML body: %v", err)
+		}
+		return result, nil
+	}
+	return string(body), nil
+}
+
+// decodeXML decodes XML into a map keyed by root element name, element attributes are prefixed with '-',
+// text of element with attributes or children is keyed with '#text', repeated elements are represented as a slice
+func decodeXML(body []byte) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("root element was missing")
+			}
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	var element = make(map[string]interface{})
+	for _, attr := range start.Attr {
+		element["-"+attr.Name.Local] = attr.Value
+	}
+	var text = new(strings.Builder)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch actual := token.(type) {
+		case xml.StartElement:
+			value, err := decodeXMLElement(decoder, actual)
+			if err != nil {
+				return nil, err
+			}
+			name := actual.Name.Local
+			if existing, ok := element[name]; ok {
+				if list, ok := existing.([]interface{}); ok {
+					element[name] = append(list, value)
+				} else {
+					element[name] = []interface{}{existing, value}
+				}
+				continue
+			}
+			element[name] = value
+		case xml.CharData:
+			text.Write(actual)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(element) == 0 {
+				return content, nil
+			}
+			if content != "" {
+				element["#text"] = content
+			}
+			return element, nil
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/assertly"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const (
+	StatusCodeKey = "StatusCode"
+	MethodKey     = "Method"
+	URLKey        = "URL"
+	HeaderKey     = "Header"
+	CookiesKey    = "Cookies"
+	BodyKey       = "Body"
+)
+
+// asHeaderMap converts header into a map with canonical keys, where each key has a sorted list of values
+func asHeaderMap(header http.Header) map[string]interface{} {
+	var values = make(map[string][]string)
+	for key, items := range header {
+		values[http.CanonicalHeaderKey(key)] = append(values[http.CanonicalHeaderKey(key)], items...)
+	}
+	return assertly.ValuesMap(values)
+}
+
+func asCookiesMap(cookies []*http.Cookie) map[string]interface{} {
+	var result = make(map[string]interface{})
+	for _, cookie := range cookies {
+		result[cookie.Name] = cookie.Value
+	}
+	return result
+}
+
+// readBody reads and decodes body by content type, the body is replaced so that it can be read again
+func readBody(body *io.ReadCloser, header http.Header) (interface{}, error) {
+	if *body == nil {
+		return "", nil
+	}
+	content, err := ioutil.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %v", err)
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(content))
+	return decodeBody(header.Get("Content-Type"), content)
+}
+
+// AsResponse converts response into a map with StatusCode, Header, Cookies and Body decoded by content type
+func AsResponse(response *http.Response) (map[string]interface{}, error) {
+	body, err := readBody(&response.Body, response.Header)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		StatusCodeKey: response.StatusCode,
+		HeaderKey:     asHeaderMap(response.Header),
+		CookiesKey:    asCookiesMap(response.Cookies()),
+		BodyKey:       body,
+	}, nil
+}
+
+// AsRequest converts request into a map with Method, URL, Header, Cookies and Body decoded by content type
+func AsRequest(request *http.Request) (map[string]interface{}, error) {
+	body, err := readBody(&request.Body, request.Header)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		MethodKey:  request.Method,
+		URLKey:     request.URL.String(),
+		HeaderKey:  asHeaderMap(request.Header),
+		CookiesKey: asCookiesMap(request.Cookies()),
+		BodyKey:    body,
+	}, nil
+}
+
+// canonicalExpected returns expected with canonical header keys
+func canonicalExpected(expected interface{}) (interface{}, error) {
+	if text, ok := expected.(string); ok {
+		var expectedMap = make(map[string]interface{})
+		if err := json.Unmarshal([]byte(text), &expectedMap); err != nil {
+			return nil, fmt.Errorf("invalid expected: %v", err)
+		}
+		expected = expectedMap
+	}
+	expectedMap, ok := expected.(map[string]interface{})
+	if !ok {
+		return expected, nil
+	}
+	header, ok := expectedMap[HeaderKey].(map[string]interface{})
+	if !ok {
+		return expected, nil
+	}
+	var result = make(map[string]interface{})
+	for k, v := range expectedMap {
+		result[k] = v
+	}
+	var canonicalHeader = make(map[string]interface{})
+	for k, v := range header {
+		if len(k) > 0 && k[0] == '@' {
+			canonicalHeader[k] = v
+			continue
+		}
+		canonicalHeader[http.CanonicalHeaderKey(k)] = v
+	}
+	result[HeaderKey] = canonicalHeader
+	return result, nil
+}
+
+// AsMap converts response, request or response recorder into assertion map
+func AsMap(actual interface{}) (map[string]interface{}, error) {
+	switch value := actual.(type) {
+	case *http.Response:
+		return AsResponse(value)
+	case *httptest.ResponseRecorder:
+		return AsResponse(value.Result())
+	case *http.Request:
+		return AsRequest(value)
+	}
+	return nil, fmt.Errorf("unsupported actual type: %T", actual)
+}
+
+// Assert validates expected against response, request or response recorder
+func Assert(expected, actual interface{}, context *assertly.Context) (*assertly.Validation, error) {
+	actualMap, err := AsMap(actual)
+	if err != nil {
+		return nil, err
+	}
+	if expected, err = canonicalExpected(expected); err != nil {
+		return nil, err
+	}
+	if context == nil {
+		context = assertly.NewDefaultContext()
+	}
+	return assertly.AssertWithContext(expected, actualMap, assertly.NewDataPath("/"), context)
+}
+
+// AssertResponse validates expected against response, request or response recorder
+func AssertResponse(t *testing.T, expected, actual interface{}, arguments ...interface{}) bool {
+	return AssertResponseWithContext(assertly.NewDefaultContext(), t, expected, actual, arguments...)
+}
+
+// AssertResponseWithContext validates expected against response, request or response recorder with context
+func AssertResponseWithContext(context *assertly.Context, t *testing.T, expected, actual interface{}, arguments ...interface{}) bool {
+	actualMap, err := AsMap(actual)
+	if err == nil {
+		expected, err = canonicalExpected(expected)
+	}
+	if err != nil {
+		t.Error(append(arguments, err)...)
+		return false
+	}
+	return assertly.AssertValuesWithContext(context, t, expected, actualMap, arguments...)
+}
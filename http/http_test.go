@@ -0,0 +1,153 @@
+package http
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAsResponse(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	recorder.Header().Set("Content-Type", "application/json; charset=utf-8")
+	recorder.Header().Add("X-Trace", "b")
+	recorder.Header().Add("X-Trace", "a")
+	http.SetCookie(recorder, &http.Cookie{Name: "session", Value: "abc"})
+	recorder.WriteHeader(http.StatusCreated)
+	_, _ = recorder.WriteString(`{"id":1,"name":"foo"}`)
+
+	response := recorder.Result()
+	actual, err := AsResponse(response)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, http.StatusCreated, actual[StatusCodeKey])
+	header := actual[HeaderKey].(map[string]interface{})
+	assert.EqualValues(t, []interface{}{"application/json; charset=utf-8"}, header["Content-Type"])
+	assert.EqualValues(t, []interface{}{"a", "b"}, header["X-Trace"])
+	assert.EqualValues(t, map[string]interface{}{"session": "abc"}, actual[CookiesKey])
+	assert.EqualValues(t, map[string]interface{}{"id": 1.0, "name": "foo"}, actual[BodyKey])
+
+	body, err := ioutil.ReadAll(response.Body)
+	assert.Nil(t, err)
+	assert.EqualValues(t, `{"id":1,"name":"foo"}`, string(body), "body should be readable after conversion")
+}
+
+func TestDecodeBody(t *testing.T) {
+	var useCases = []struct {
+		description string
+		contentType string
+		body        string
+		expected    interface{}
+		hasError    bool
+	}{
+		{
+			description: "json",
+			contentType: "application/json",
+			body:        `[1,"a"]`,
+			expected:    []interface{}{1.0, "a"},
+		},
+		{
+			description: "json suffix",
+			contentType: "application/problem+json",
+			body:        `{"title":"bad"}`,
+			expected:    map[string]interface{}{"title": "bad"},
+		},
+		{
+			description: "form",
+			contentType: "application/x-www-form-urlencoded",
+			body:        "a=1&b=3&b=2",
+			expected:    map[string]interface{}{"a": []interface{}{"1"}, "b": []interface{}{"2", "3"}},
+		},
+		{
+			description: "xml",
+			contentType: "text/xml",
+			body:        `<user id="1"><name>foo</name><role>a</role><role>b</role><note lang="en">hi</note></user>`,
+			expected: map[string]interface{}{
+				"user": map[string]interface{}{
+					"-id":  "1",
+					"name": "foo",
+					"role": []interface{}{"a", "b"},
+					"note": map[string]interface{}{"-lang": "en", "#text": "hi"},
+				},
+			},
+		},
+		{
+			description: "text",
+			contentType: "text/plain",
+			body:        "hello",
+			expected:    "hello",
+		},
+		{
+			description: "empty",
+			contentType: "application/json",
+			body:        "",
+			expected:    "",
+		},
+		{
+			description: "invalid json",
+			contentType: "application/json",
+			body:        "{",
+			hasError:    true,
+		},
+	}
+	for _, useCase := range useCases {
+		actual, err := decodeBody(useCase.contentType, []byte(useCase.body))
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.expected, actual, useCase.description)
+	}
+}
+
+func TestAssert(t *testing.T) {
+	handler := http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("content-type", "application/json")
+		writer.WriteHeader(http.StatusOK)
+		_, _ = writer.Write([]byte(`{"id":1,"items":[{"name":"a"},{"name":"b"}]}`))
+	})
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/", nil))
+
+	validation, err := Assert(map[string]interface{}{
+		"StatusCode": 200,
+		"Header": map[string]interface{}{
+			"content-type": "~/json/",
+		},
+		"Body": map[string]interface{}{
+			"@length@items": 2,
+			"id":            1,
+		},
+	}, recorder, nil)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 0, validation.FailedCount, validation.Report())
+	}
+	assert.True(t, AssertResponse(t, `{"StatusCode":200, "Body":{"items":[{"name":"a"}, {"name":"b"}]}}`, recorder))
+
+	validation, err = Assert(map[string]interface{}{"StatusCode": 404}, recorder, nil)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 1, validation.FailedCount)
+	}
+
+	request := httptest.NewRequest("POST", "/users?x=1", strings.NewReader("name=foo"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.AddCookie(&http.Cookie{Name: "token", Value: "t1"})
+	validation, err = Assert(map[string]interface{}{
+		"Method":  "POST",
+		"URL":     "/users?x=1",
+		"Cookies": map[string]interface{}{"token": "t1"},
+		"Body":    map[string]interface{}{"name": "foo"},
+	}, request, nil)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 0, validation.FailedCount, validation.Report())
+	}
+
+	_, err = Assert(map[string]interface{}{}, "abc", nil)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,128 @@
+package assertly
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// keyPattern represents expected map key matching actual keys with regular expression (~/expr/) or glob (~expr)
+type keyPattern struct {
+	key    string
+	regExp *regexp.Regexp
+	glob   string
+}
+
+// isKeyPattern returns true if expected map key is a regular expression (~/expr/) or a glob with *, ? or [ (~expr) key pattern,
+// other keys starting with '~' are literal keys
+func isKeyPattern(key string) bool {
+	if !strings.HasPrefix(key, "~") {
+		return false
+	}
+	expr := key[1:]
+	return isRegExpKeyPattern(expr) || strings.ContainsAny(expr, "*?[")
+}
+
+func isRegExpKeyPattern(expr string) bool {
+	return len(expr) > 2 && strings.HasPrefix(expr, "/") && strings.HasSuffix(expr, "/")
+}
+
+func newKeyPattern(key string) (*keyPattern, error) {
+	if !isKeyPattern(key) {
+		return nil, fmt.Errorf("invalid key pattern %q, expected ~/expr/ or ~expr with *, ? or [ wildcards", key)
+	}
+	var result = &keyPattern{key: key}
+	expr := key[1:]
+	if isRegExpKeyPattern(expr) {
+		var err error
+		if result.regExp, err = regexp.Compile(expr[1 : len(expr)-1]); err != nil {
+			return nil, fmt.Errorf("invalid key pattern %v, %v", key, err)
+		}
+		return result, nil
+	}
+	if _, err := filepath.Match(expr, ""); err != nil {
+		return nil, fmt.Errorf("invalid key pattern %v, %v", key, err)
+	}
+	result.glob = expr
+	return result, nil
+}
+
+// Match returns true if actual key matches the pattern
+func (p *keyPattern) Match(key string) bool {
+	if p.regExp != nil {
+		return p.regExp.MatchString(key)
+	}
+	matched, _ := filepath.Match(p.glob, key)
+	return matched
+}
+
+// matchingKeys returns sorted actual keys matching pattern, excluding keys defined explicitly in expected map
+func (p *keyPattern) matchingKeys(expected, actual map[string]interface{}) []string {
+	var result = make([]string, 0)
+	for key := range actual {
+		if _, ok := expected[key]; ok {
+			continue
+		}
+		if p.Match(key) {
+			result = append(result, key)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// assertKeyPatterns applies expected pattern key values to every matching actual key and asserts matching keys count,
+// by default at least one actual key has to match, it returns pattern and matched actual keys
+func assertKeyPatterns(expected, actual map[string]interface{}, directive *Directive, path DataPath, context *Context, validation *Validation) (map[string]bool, error) {
+	var checked = make(map[string]bool)
+	var counts = make(map[string]interface{})
+	for key := range expected {
+		if isKeyPattern(key) {
+			counts[key] = ">0"
+		}
+	}
+	for key := range directive.KeyDoesNotExist {
+		if isKeyPattern(key) {
+			counts[key] = 0
+		}
+	}
+	for key, count := range directive.KeyCounts {
+		if !isKeyPattern(key) {
+			return nil, fmt.Errorf("invalid %v%v key pattern, expected ~/expr/ or ~expr with *, ? or [ wildcards, path: %v", KeyCountDirective, key, path.Path())
+		}
+		counts[key] = count
+	}
+	for key, count := range counts {
+		checked[key] = true
+		pattern, err := newKeyPattern(key)
+		if err != nil {
+			return nil, fmt.Errorf("%v, path: %v", err, path.Path())
+		}
+		matched := pattern.matchingKeys(expected, actual)
+		for _, actualKey := range matched {
+			checked[actualKey] = true
+		}
+		isValid, err := matchCount(count, len(matched))
+		if err != nil {
+			return nil, fmt.Errorf("%v, path: %v", err, path.Path())
+		}
+		if !isValid {
+			keyPath := path.Key(key)
+			validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), KeyCountViolation, count, len(matched), matched))
+			continue
+		}
+		expectedValue, ok := expected[key]
+		if !ok || directive.KeyExists[key] || directive.KeyDoesNotExist[key] {
+			validation.PassedCount++
+			continue
+		}
+		for _, actualKey := range matched {
+			if err := assertValue(expectedValue, actual[actualKey], path.Key(actualKey), context, validation); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return checked, nil
+}
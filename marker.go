@@ -0,0 +1,65 @@
+package assertly
+
+import (
+	"github.com/viant/toolbox"
+	"strings"
+)
+
+// unwrapMarker returns expected value wrapped with supplied marker, i.e. {"@nullable@": expected},
+// other directives defined in the wrapper map are added to map expected value
+func unwrapMarker(expected interface{}, marker string) (interface{}, bool) {
+	if expected == nil || !toolbox.IsMap(expected) {
+		return nil, false
+	}
+	aMap := toolbox.AsMap(expected)
+	value, ok := aMap[marker]
+	if !ok {
+		return nil, false
+	}
+	var directives = make(map[string]interface{})
+	for key, directive := range aMap {
+		if key == marker {
+			continue
+		}
+		if !(strings.HasPrefix(key, "@") && strings.Count(key, "@") > 1) {
+			return nil, false
+		}
+		directives[key] = directive
+	}
+	if len(directives) == 0 || value == nil || !toolbox.IsMap(value) {
+		return value, true
+	}
+	var result = make(map[string]interface{})
+	for k, v := range directives {
+		result[k] = v
+	}
+	for k, v := range toolbox.AsMap(value) {
+		result[k] = v
+	}
+	return result, true
+}
+
+// unwrapMarkers returns expected value with optional and nullable markers removed
+func unwrapMarkers(expected interface{}) (value interface{}, optional, nullable bool) {
+	value = expected
+	for {
+		if unwrapped, ok := unwrapMarker(value, OptionalDirective); ok {
+			value, optional = unwrapped, true
+			continue
+		}
+		if unwrapped, ok := unwrapMarker(value, NullableDirective); ok {
+			value, nullable = unwrapped, true
+			continue
+		}
+		return value, optional, nullable
+	}
+}
+
+// isValueWrapper returns true if supplied map wraps expected value rather than defines slice directives
+func isValueWrapper(aMap map[string]interface{}) bool {
+	if _, _, ok := alternativesOf(aMap); ok {
+		return true
+	}
+	_, optional, nullable := unwrapMarkers(aMap)
+	return optional || nullable
+}
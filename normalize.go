@@ -0,0 +1,73 @@
+package assertly
+
+import (
+	"fmt"
+	"golang.org/x/text/unicode/norm"
+	"regexp"
+	"strings"
+)
+
+const (
+	TrimNormalization        = "trim"
+	WhitespaceNormalization  = "whitespace"
+	LineEndingsNormalization = "lineEndings"
+	BlankLinesNormalization  = "blankLines"
+	NFCNormalization         = "nfc"
+	NFDNormalization         = "nfd"
+	NFKCNormalization        = "nfkc"
+	NFKDNormalization        = "nfkd"
+)
+
+var whitespaceExpr = regexp.MustCompile(`\s+`)
+
+var normalizationOrder = []string{LineEndingsNormalization, NFCNormalization, NFDNormalization, NFKCNormalization, NFKDNormalization, BlankLinesNormalization, WhitespaceNormalization, TrimNormalization}
+
+// normalizeText applies supplied text normalizations, line endings are normalized first, followed by unicode form, blank lines, whitespace and trim
+func normalizeText(text string, normalizations []string) (string, error) {
+	var enabled = make(map[string]bool)
+	for _, normalization := range normalizations {
+		normalization = strings.ToLower(strings.TrimSpace(normalization))
+		if normalization == "" {
+			continue
+		}
+		var supported = false
+		for _, candidate := range normalizationOrder {
+			if strings.ToLower(candidate) == normalization {
+				enabled[candidate], supported = true, true
+			}
+		}
+		if !supported {
+			return "", fmt.Errorf("unsupported text normalization: %v", normalization)
+		}
+	}
+	for _, normalization := range normalizationOrder {
+		if !enabled[normalization] {
+			continue
+		}
+		switch normalization {
+		case LineEndingsNormalization:
+			text = strings.Replace(strings.Replace(text, "\r\n", "\n", -1), "\r", "\n", -1)
+		case NFCNormalization:
+			text = norm.NFC.String(text)
+		case NFDNormalization:
+			text = norm.NFD.String(text)
+		case NFKCNormalization:
+			text = norm.NFKC.String(text)
+		case NFKDNormalization:
+			text = norm.NFKD.String(text)
+		case BlankLinesNormalization:
+			var lines = make([]string, 0)
+			for _, line := range strings.Split(text, "\n") {
+				if strings.TrimSpace(line) != "" {
+					lines = append(lines, line)
+				}
+			}
+			text = strings.Join(lines, "\n")
+		case WhitespaceNormalization:
+			text = whitespaceExpr.ReplaceAllString(text, " ")
+		case TrimNormalization:
+			text = strings.TrimSpace(text)
+		}
+	}
+	return text, nil
+}
@@ -0,0 +1,107 @@
+package assertly
+
+import (
+	"github.com/viant/toolbox"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultParallelThreshold represents default minimum number of slice items or map entries to assert concurrently
+const DefaultParallelThreshold = 1000
+
+var contextMutexKey = (*sync.Mutex)(nil)
+
+// contextMutex returns a mutex guarding supplied context and captured variables, mutex is created if needed
+func contextMutex(context toolbox.Context) *sync.Mutex {
+	if context.Contains(contextMutexKey) {
+		if mutex, ok := context.GetOptional(contextMutexKey).(*sync.Mutex); ok {
+			return mutex
+		}
+	}
+	var mutex = &sync.Mutex{}
+	_ = context.Replace(contextMutexKey, mutex)
+	return mutex
+}
+
+// lock locks shared toolbox context and macro evaluator, it returns unlock function
+func (c *Context) lock() func() {
+	if c.mutex == nil {
+		return func() {}
+	}
+	c.mutex.Lock()
+	return c.mutex.Unlock
+}
+
+// workers returns number of workers to assert supplied number of items, 1 means sequential assertion;
+// parallel mode requires context created with NewContext
+func (c *Context) workers(count int) int {
+	if c.Parallelism <= 1 || c.mutex == nil {
+		return 1
+	}
+	threshold := c.ParallelThreshold
+	if threshold <= 0 {
+		threshold = DefaultParallelThreshold
+	}
+	if count < threshold {
+		return 1
+	}
+	if c.Parallelism > count {
+		return count
+	}
+	return c.Parallelism
+}
+
+// valueAssertion represents deferred expected and actual value assertion
+type valueAssertion struct {
+	expected interface{}
+	actual   interface{}
+	path     DataPath
+}
+
+// isolatePath assigns path its own directive copy, so that concurrent assertions do not share directive state
+func isolatePath(path DataPath) DataPath {
+	if aPath, ok := path.(*dataPath); ok && aPath.directive != nil {
+		aPath.directive = aPath.directive.clone(aPath)
+	}
+	return path
+}
+
+// assertConcurrently asserts values with a worker pool, each assertion uses its own validation,
+// validations are merged in assertions order, so that the result does not depend on scheduling
+func assertConcurrently(assertions []*valueAssertion, workers int, context *Context, validation *Validation) error {
+	var validations = make([]*Validation, len(assertions))
+	var errors = make([]error, len(assertions))
+	var next int32 = -1
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer waitGroup.Done()
+			for {
+				index := int(atomic.AddInt32(&next, 1))
+				if index >= len(assertions) {
+					return
+				}
+				assertion := assertions[index]
+				validations[index] = newTrialValidation()
+				errors[index] = assertValue(assertion.expected, assertion.actual, assertion.path, context, validations[index])
+			}
+		}()
+	}
+	waitGroup.Wait()
+	for i := range assertions {
+		if errors[i] != nil {
+			return errors[i]
+		}
+		validation.MergeFrom(validations[i])
+	}
+	return nil
+}
+
+// sortAssertions sorts assertions by path
+func sortAssertions(assertions []*valueAssertion) {
+	sort.Slice(assertions, func(i, j int) bool {
+		return assertions[i].path.Path() < assertions[j].path.Path()
+	})
+}
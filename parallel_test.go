@@ -0,0 +1,104 @@
+package assertly_test
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"testing"
+)
+
+func parallelTestData(count int) ([]interface{}, []interface{}) {
+	var expected = []interface{}{
+		map[string]interface{}{"@timeFormat@modified": "yyyy-MM-dd"},
+	}
+	var actual = make([]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		expected = append(expected, map[string]interface{}{
+			"id":       i,
+			"score":    "/[0..1000]/",
+			"token":    `<ds:capture["token"]>`,
+			"modified": "2018-01-15",
+			"tags":     []interface{}{fmt.Sprintf("t%v", i), "common"},
+		})
+		var score = i
+		if i%10 == 0 {
+			score = 2000
+		}
+		actual = append(actual, map[string]interface{}{
+			"id":       i,
+			"score":    score,
+			"token":    fmt.Sprintf("token%v", i),
+			"modified": "2018-01-15",
+			"tags":     []interface{}{fmt.Sprintf("t%v", i), "common"},
+		})
+	}
+	return expected, actual
+}
+
+func failurePaths(validation *assertly.Validation) []string {
+	var result = make([]string, 0)
+	for _, failure := range validation.Failures {
+		result = append(result, failure.Path)
+	}
+	return result
+}
+
+func TestAssertParallel(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		expected, actual := parallelTestData(200)
+		if indexed {
+			expected[0].(map[string]interface{})["@indexBy@"] = "id"
+		}
+		sequentialContext := assertly.NewDefaultContext()
+		sequential, err := assertly.AssertWithContext(expected, actual, assertly.NewDataPath("/"), sequentialContext)
+		if !assert.Nil(t, err) {
+			continue
+		}
+		assert.EqualValues(t, 20, sequential.FailedCount)
+		if !indexed {
+			assert.EqualValues(t, "token199", sequentialContext.Variables()["token"])
+		}
+
+		var previous []string
+		for i := 0; i < 3; i++ {
+			expected, actual := parallelTestData(200)
+			if indexed {
+				expected[0].(map[string]interface{})["@indexBy@"] = "id"
+			}
+			context := assertly.NewDefaultContext()
+			context.Parallelism = 4
+			context.ParallelThreshold = 10
+			validation, err := assertly.AssertWithContext(expected, actual, assertly.NewDataPath("/"), context)
+			if !assert.Nil(t, err) {
+				continue
+			}
+			assert.EqualValues(t, sequential.PassedCount, validation.PassedCount, fmt.Sprintf("indexed: %v", indexed))
+			assert.EqualValues(t, sequential.FailedCount, validation.FailedCount, fmt.Sprintf("indexed: %v", indexed))
+			if indexed {
+				assert.EqualValues(t, "token99", context.Variables()["token"], "captures should be stored in item path order")
+			} else {
+				assert.EqualValues(t, "token199", context.Variables()["token"], "captures should be stored in item index order")
+			}
+			paths := failurePaths(validation)
+			if !indexed {
+				assert.EqualValues(t, failurePaths(sequential), paths)
+			}
+			if previous != nil {
+				assert.EqualValues(t, previous, paths, "failures should be merged deterministically")
+			}
+			previous = paths
+		}
+	}
+}
+
+func TestAssertParallelLength(t *testing.T) {
+	expected, actual := parallelTestData(50)
+	context := assertly.NewDefaultContext()
+	context.Parallelism = 8
+	context.ParallelThreshold = 10
+	validation, err := assertly.AssertWithContext(expected, actual[:40], assertly.NewDataPath("/"), context)
+	if assert.Nil(t, err) {
+		assert.EqualValues(t, 5, validation.FailedCount)
+		assert.EqualValues(t, assertly.LengthViolation, validation.Failures[4].Reason)
+	}
+}
@@ -67,6 +67,9 @@ func (p *dataPath) Key(field string) DataPath {
 			}
 		}
 		keyDirective.mergeFrom(p.directive)
+		if normalize, ok := p.directive.KeyNormalize[field]; ok {
+			keyDirective.Normalize = normalize
+		}
 	}
 	return keyPath
 }
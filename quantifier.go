@@ -0,0 +1,151 @@
+package assertly
+
+import (
+	"fmt"
+)
+
+// itemMatch represents expected template assertion outcome for a slice item
+type itemMatch struct {
+	index      int
+	item       interface{}
+	validation *Validation
+}
+
+func (m *itemMatch) matched() bool {
+	return !m.validation.HasFailure()
+}
+
+// matchItems asserts expected template against each actual item into isolated validation
+func matchItems(expected interface{}, actual []interface{}, path DataPath, context *Context) ([]*itemMatch, error) {
+	var result = make([]*itemMatch, 0, len(actual))
+	for i, item := range actual {
+		match := &itemMatch{index: i, item: item, validation: newTrialValidation()}
+		if err := assertValue(cloneValue(expected), cloneValue(item), path.Index(i), context, match.validation); err != nil {
+			return nil, err
+		}
+		result = append(result, match)
+	}
+	return result, nil
+}
+
+// firstMatch returns the first matched item
+func firstMatch(matches []*itemMatch) *itemMatch {
+	for _, match := range matches {
+		if match.matched() {
+			return match
+		}
+	}
+	return nil
+}
+
+// closestMatch returns not matched item with the fewest failures
+func closestMatch(matches []*itemMatch) *itemMatch {
+	var result *itemMatch
+	for _, match := range matches {
+		if match.matched() {
+			continue
+		}
+		if result == nil || match.validation.FailedCount < result.validation.FailedCount {
+			result = match
+		}
+	}
+	return result
+}
+
+func formatMismatches(failures []*Failure) string {
+	var result = ""
+	for i, failure := range failures {
+		if i > 0 {
+			result += "; "
+		}
+		failure.render()
+		result += failure.Path + ": " + failure.Message
+	}
+	return result
+}
+
+func assertQuantifiers(directive *Directive, actual []interface{}, path DataPath, context *Context, validation *Validation) error {
+	some, none, counts := directive.Some, directive.None, directive.Counts
+	//reset directive, item paths share it with the slice path
+	directive.Some, directive.None, directive.Counts = nil, nil, nil
+
+	for _, expected := range some {
+		matches, err := matchItems(expected, actual, path, context)
+		if err != nil {
+			return err
+		}
+		if first := firstMatch(matches); first != nil {
+			validation.PassedCount++
+			validation.mergeCaptures(first.validation)
+			continue
+		}
+		var closestIndex = -1
+		var closestItem interface{}
+		var mismatches []*Failure
+		if closest := closestMatch(matches); closest != nil {
+			closestIndex, closestItem, mismatches = closest.index, closest.item, closest.validation.Failures
+		}
+		validation.AddFailure(newFailure(path.Source(), path.Path(), SomeViolation, expected, closestItem, len(actual), closestIndex, mismatches))
+	}
+
+	for _, expected := range none {
+		matches, err := matchItems(expected, actual, path, context)
+		if err != nil {
+			return err
+		}
+		if countMatched(matches) == 0 {
+			validation.PassedCount++
+			continue
+		}
+		for _, match := range matches {
+			if match.matched() {
+				indexPath := path.Index(match.index)
+				validation.AddFailure(newFailure(indexPath.Source(), indexPath.Path(), NoneViolation, expected, match.item))
+			}
+		}
+	}
+
+	for _, count := range counts {
+		matches, err := matchItems(count.Expected, actual, path, context)
+		if err != nil {
+			return err
+		}
+		matchedCount := countMatched(matches)
+		isValid, err := matchCount(count.Count, matchedCount)
+		if err != nil {
+			return fmt.Errorf("%v, path: %v", err, path.Path())
+		}
+		if isValid {
+			validation.PassedCount++
+			for _, match := range matches {
+				if match.matched() {
+					validation.mergeCaptures(match.validation)
+				}
+			}
+			continue
+		}
+		var matchedIndexes = make([]int, 0)
+		for _, match := range matches {
+			if match.matched() {
+				matchedIndexes = append(matchedIndexes, match.index)
+			}
+		}
+		var closestIndex = -1
+		var mismatches []*Failure
+		if closest := closestMatch(matches); closest != nil {
+			closestIndex, mismatches = closest.index, closest.validation.Failures
+		}
+		validation.AddFailure(newFailure(path.Source(), path.Path(), CountViolation, count.Count, matchedCount, count.Expected, matchedIndexes, closestIndex, mismatches))
+	}
+	return nil
+}
+
+func countMatched(matches []*itemMatch) int {
+	var result = 0
+	for _, match := range matches {
+		if match.matched() {
+			result++
+		}
+	}
+	return result
+}
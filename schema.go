@@ -0,0 +1,153 @@
+package assertly
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const inlineSchemaURL = "assertly://schema.json"
+
+var compiledSchemas = &sync.Map{}
+
+// compileSchema compiles inline JSON schema (map or JSON text) or schema file location, draft 2020-12 is used unless $schema is specified
+func compileSchema(source interface{}) (*jsonschema.Schema, error) {
+	var location, inline string
+	if text, ok := source.(string); ok {
+		text = strings.TrimSpace(text)
+		if toolbox.IsCompleteJSON(text) {
+			inline = text
+		} else {
+			location = text
+		}
+	} else {
+		encoded, err := json.Marshal(source)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema: %v", err)
+		}
+		inline = string(encoded)
+	}
+	var key = location + inline
+	if schema, ok := compiledSchemas.Load(key); ok {
+		return schema.(*jsonschema.Schema), nil
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft2020
+	if inline != "" {
+		location = inlineSchemaURL
+		if err := compiler.AddResource(location, strings.NewReader(inline)); err != nil {
+			return nil, fmt.Errorf("invalid schema: %v", err)
+		}
+	}
+	schema, err := compiler.Compile(location)
+	if err != nil {
+		return nil, err
+	}
+	compiledSchemas.Store(key, schema)
+	return schema, nil
+}
+
+// asJSONValue converts supplied value into JSON decoded data structure
+func asJSONValue(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var result interface{}
+	err = decoder.Decode(&result)
+	return result, err
+}
+
+// schemaInstancePath returns data path and value for supplied schema instance location (JSON pointer)
+func schemaInstancePath(path DataPath, location string, value interface{}) (DataPath, interface{}) {
+	location = strings.Trim(location, "/")
+	if location == "" {
+		return path, value
+	}
+	for _, token := range strings.Split(location, "/") {
+		token = strings.Replace(strings.Replace(token, "~1", "/", -1), "~0", "~", -1)
+		if aSlice, ok := value.([]interface{}); ok {
+			if index, err := strconv.Atoi(token); err == nil && index < len(aSlice) {
+				path = path.Index(index)
+				value = aSlice[index]
+				continue
+			}
+		}
+		path = path.Key(token)
+		if aMap, ok := value.(map[string]interface{}); ok {
+			value = aMap[token]
+		} else {
+			value = nil
+		}
+	}
+	return path, value
+}
+
+func schemaViolations(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	var result = make([]*jsonschema.ValidationError, 0)
+	for _, cause := range err.Causes {
+		result = append(result, schemaViolations(cause)...)
+	}
+	return result
+}
+
+func assertSchema(schemaSource, actual interface{}, path DataPath, validation *Validation) error {
+	schema, err := compileSchema(schemaSource)
+	if err != nil {
+		return fmt.Errorf("failed to compile schema, path: %v, %v", path.Path(), err)
+	}
+	if text, ok := actual.(string); ok && (toolbox.IsCompleteJSON(text) || toolbox.IsNewLineDelimitedJSON(text)) {
+		actual = asDataStructure(text)
+	}
+	value, err := asJSONValue(actual)
+	if err != nil {
+		return fmt.Errorf("failed to convert %T to JSON, path: %v, %v", actual, path.Path(), err)
+	}
+	err = schema.Validate(value)
+	if err == nil {
+		validation.PassedCount++
+		return nil
+	}
+	validationError, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return fmt.Errorf("failed to validate schema, path: %v, %v", path.Path(), err)
+	}
+	for _, violation := range schemaViolations(validationError) {
+		instancePath, instance := schemaInstancePath(path, violation.InstanceLocation, value)
+		validation.AddFailure(newFailure(instancePath.Source(), instancePath.Path(), SchemaViolation, violation.KeywordLocation, instance, violation.Message))
+	}
+	return nil
+}
+
+func assertSchemas(schemas map[string]interface{}, actualValue interface{}, actual map[string]interface{}, path DataPath, validation *Validation) error {
+	actualMap := data.Map(actual)
+	for key, schema := range schemas {
+		if key == "" {
+			if err := assertSchema(schema, actualValue, path, validation); err != nil {
+				return err
+			}
+			continue
+		}
+		keyPath := path.Key(key)
+		value, ok := actualMap.GetValue(key)
+		if !ok {
+			validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), KeyExistsViolation, key, actual))
+			continue
+		}
+		if err := assertSchema(schema, value, keyPath, validation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
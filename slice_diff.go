@@ -0,0 +1,126 @@
+package assertly
+
+import (
+	"encoding/json"
+	"github.com/viant/toolbox"
+	"hash/fnv"
+)
+
+// maxChangedBlockCells limits expected by actual items of a changed block aligned with item assertion,
+// larger blocks are paired by position
+const maxChangedBlockCells = 64 * 64
+
+// canonicalItem returns item JSON representation without directive keys
+func canonicalItem(item interface{}) string {
+	if item != nil && toolbox.IsMap(item) {
+		item = removeDirectives(toolbox.AsMap(item))
+	}
+	if encoded, err := json.Marshal(item); err == nil {
+		return string(encoded)
+	}
+	return toolbox.AsString(item)
+}
+
+// itemHashes returns canonical item hashes
+func itemHashes(items []interface{}) []uint64 {
+	var result = make([]uint64, len(items))
+	for i, item := range items {
+		hash := fnv.New64a()
+		_, _ = hash.Write([]byte(canonicalItem(item)))
+		result[i] = hash.Sum64()
+	}
+	return result
+}
+
+// assertAlignedItems aligns expected with actual items using longest common subsequence on canonical item hashes,
+// aligned items are asserted, expected and actual items between aligned ones form changed blocks asserted with assertChangedItems
+func assertAlignedItems(expected, actual []interface{}, path DataPath, context *Context, validation *Validation) error {
+	expectedHashes, actualHashes := itemHashes(expected), itemHashes(actual)
+	edits := diffIndexes(len(expected), len(actual), func(i, j int) bool {
+		return expectedHashes[i] == actualHashes[j]
+	})
+	for k := 0; k < len(edits); {
+		if edits[k].Op == DiffEqual {
+			if err := assertValue(expected[edits[k].Expected], actual[edits[k].Actual], path.Index(edits[k].Actual), context, validation); err != nil {
+				return err
+			}
+			k++
+			continue
+		}
+		var removed, inserted = make([]int, 0), make([]int, 0)
+		for ; k < len(edits) && edits[k].Op != DiffEqual; k++ {
+			if edits[k].Op == DiffDelete {
+				removed = append(removed, edits[k].Expected)
+			} else {
+				inserted = append(inserted, edits[k].Actual)
+			}
+		}
+		if err := assertChangedItems(expected, actual, removed, inserted, path, context, validation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assertChangedItems aligns changed block items with longest common subsequence refined with item assertion,
+// so that expressions and directives are honoured, blocks exceeding maxChangedBlockCells are not refined;
+// aligned items are asserted, the remaining ones are asserted with assertUnalignedItems
+func assertChangedItems(expected, actual []interface{}, removed, inserted []int, path DataPath, context *Context, validation *Validation) error {
+	if len(removed) == 0 || len(inserted) == 0 || len(removed)*len(inserted) > maxChangedBlockCells {
+		return assertUnalignedItems(expected, actual, removed, inserted, path, context, validation)
+	}
+	var matched = make(map[int]bool)
+	var err error
+	edits := diffIndexes(len(removed), len(inserted), func(i, j int) bool {
+		key := i*len(inserted) + j
+		if isMatched, ok := matched[key]; ok {
+			return isMatched
+		}
+		itemValidation := newTrialValidation()
+		if e := assertValue(cloneValue(expected[removed[i]]), cloneValue(actual[inserted[j]]), path.Index(inserted[j]), context, itemValidation); e != nil && err == nil {
+			err = e
+		}
+		matched[key] = !itemValidation.HasFailure()
+		return matched[key]
+	})
+	if err != nil {
+		return err
+	}
+	var unalignedRemoved, unalignedInserted = make([]int, 0), make([]int, 0)
+	for _, edit := range edits {
+		switch edit.Op {
+		case DiffDelete:
+			unalignedRemoved = append(unalignedRemoved, removed[edit.Expected])
+		case DiffInsert:
+			unalignedInserted = append(unalignedInserted, inserted[edit.Actual])
+		default:
+			if err := assertUnalignedItems(expected, actual, unalignedRemoved, unalignedInserted, path, context, validation); err != nil {
+				return err
+			}
+			unalignedRemoved, unalignedInserted = unalignedRemoved[:0], unalignedInserted[:0]
+			if err := assertValue(expected[removed[edit.Expected]], actual[inserted[edit.Actual]], path.Index(inserted[edit.Actual]), context, validation); err != nil {
+				return err
+			}
+		}
+	}
+	return assertUnalignedItems(expected, actual, unalignedRemoved, unalignedInserted, path, context, validation)
+}
+
+// assertUnalignedItems pairs unaligned expected items with unaligned actual items as changed items,
+// remaining expected items are reported as missing and remaining actual items as unexpected
+func assertUnalignedItems(expected, actual []interface{}, removed, inserted []int, path DataPath, context *Context, validation *Validation) error {
+	for len(removed) > 0 && len(inserted) > 0 {
+		if err := assertValue(expected[removed[0]], actual[inserted[0]], path.Index(inserted[0]), context, validation); err != nil {
+			return err
+		}
+		removed, inserted = removed[1:], inserted[1:]
+	}
+	for _, i := range removed {
+		validation.AddFailure(newFailure(path.Source(), path.Path(), MissingItemViolation, expected[i], nil, i))
+	}
+	for _, j := range inserted {
+		itemPath := path.Index(j)
+		validation.AddFailure(newFailure(itemPath.Source(), itemPath.Path(), UnexpectedItemViolation, nil, actual[j]))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package assertly
+
+import (
+	"encoding/json"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	textCollation   = "text"
+	numberCollation = "number"
+	timeCollation   = "time"
+)
+
+// sortKey represents slice item sort key
+type sortKey struct {
+	field      string
+	descending bool
+	collation  string
+}
+
+// parseSortKeys parses sort keys, each key is a field optionally followed by asc or desc or prefixed with '-' for descending order,
+// key without field (asc or desc) represents the whole item; field can be suffixed with :text, :number or :time collation
+func parseSortKeys(keys []string) []*sortKey {
+	var result = make([]*sortKey, 0)
+	for _, text := range keys {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		var key = &sortKey{}
+		fragments := strings.Fields(text)
+		switch strings.ToLower(fragments[len(fragments)-1]) {
+		case "asc":
+			fragments = fragments[:len(fragments)-1]
+		case "desc":
+			key.descending = true
+			fragments = fragments[:len(fragments)-1]
+		}
+		key.field = strings.Join(fragments, " ")
+		if strings.HasPrefix(key.field, "-") {
+			key.descending = true
+			key.field = key.field[1:]
+		}
+		if index := strings.LastIndex(key.field, ":"); index != -1 {
+			switch collation := strings.ToLower(key.field[index+1:]); collation {
+			case textCollation, numberCollation, timeCollation:
+				key.collation = collation
+				key.field = key.field[:index]
+			}
+		}
+		result = append(result, key)
+	}
+	return result
+}
+
+// value returns item field value, or the whole item with maps and slices represented as canonical JSON
+func (k *sortKey) value(item interface{}) interface{} {
+	if k.field == "" {
+		if item != nil && (toolbox.IsMap(item) || toolbox.IsSlice(item) || toolbox.IsStruct(item)) {
+			if encoded, err := json.Marshal(item); err == nil {
+				return string(encoded)
+			}
+		}
+		return item
+	}
+	if item == nil || !toolbox.IsMap(item) {
+		return nil
+	}
+	aMap := data.Map(toolbox.AsMap(item))
+	value, _ := aMap.GetValue(k.field)
+	return value
+}
+
+// collate returns value collation rank (numbers, time, then text) and value to compare within the rank;
+// without explicit collation numeric values are collated as numbers, time (RFC3339 or time layout) values as time, others as text
+func (k *sortKey) collate(value interface{}, timeLayout string) (int, interface{}) {
+	if (k.collation == "" || k.collation == numberCollation) && !toolbox.IsBool(value) {
+		if number, err := toolbox.ToFloat(value); err == nil {
+			return 0, number
+		}
+	}
+	if k.collation == "" || k.collation == timeCollation {
+		if timeValue := asOperandTime(value, timeLayout); timeValue != nil {
+			return 1, *timeValue
+		}
+	}
+	return 2, toolbox.AsString(value)
+}
+
+// compare compares key values with nil ordered first, values of different collation rank are ordered by rank,
+// so that the order is consistent for mixed values
+func (k *sortKey) compare(left, right interface{}, timeLayout string) int {
+	if left == nil || right == nil {
+		if left == nil && right == nil {
+			return 0
+		} else if left == nil {
+			return -1
+		}
+		return 1
+	}
+	leftRank, leftValue := k.collate(left, timeLayout)
+	rightRank, rightValue := k.collate(right, timeLayout)
+	if leftRank != rightRank {
+		return leftRank - rightRank
+	}
+	switch leftCollated := leftValue.(type) {
+	case float64:
+		return compareFloats(leftCollated, rightValue.(float64))
+	case time.Time:
+		rightTime := rightValue.(time.Time)
+		if leftCollated.Before(rightTime) {
+			return -1
+		} else if leftCollated.After(rightTime) {
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(leftValue.(string), rightValue.(string))
+}
+
+func sortKeyValues(keys []*sortKey, item interface{}) []interface{} {
+	var result = make([]interface{}, len(keys))
+	for i, key := range keys {
+		result[i] = key.value(item)
+	}
+	return result
+}
+
+// compareItems compares items by sort keys
+func compareItems(keys []*sortKey, left, right interface{}, timeLayout string) int {
+	for _, key := range keys {
+		comparison := key.compare(key.value(left), key.value(right), timeLayout)
+		if key.descending {
+			comparison = -comparison
+		}
+		if comparison != 0 {
+			return comparison
+		}
+	}
+	return 0
+}
+
+// sortItems returns a copy of items ordered by sort keys, items with equal keys keep their order
+func sortItems(items []interface{}, keys []*sortKey, timeLayout string) []interface{} {
+	var result = make([]interface{}, len(items))
+	copy(result, items)
+	sort.SliceStable(result, func(i, j int) bool {
+		return compareItems(keys, result[i], result[j], timeLayout) < 0
+	})
+	return result
+}
+
+func assertSorted(sortedBy []string, actual []interface{}, path DataPath, context *Context, validation *Validation) {
+	keys := parseSortKeys(sortedBy)
+	timeLayout := path.Match(context).DefaultTimeLayout()
+	for i := 1; i < len(actual); i++ {
+		if compareItems(keys, actual[i-1], actual[i], timeLayout) > 0 {
+			itemPath, previousPath := path.Index(i), path.Index(i-1)
+			validation.AddFailure(newFailure(itemPath.Source(), itemPath.Path(), SortedViolation, strings.Join(sortedBy, ","), sortKeyValues(keys, actual[i]), previousPath.Path(), sortKeyValues(keys, actual[i-1])))
+			return
+		}
+	}
+	validation.PassedCount++
+}
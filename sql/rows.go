@@ -0,0 +1,54 @@
+package sql
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/viant/assertly"
+)
+
+// ReadRows reads rows into a slice of maps keyed by column name, column values keep driver types (NULL is read as nil)
+func ReadRows(rows *sql.Rows) ([]interface{}, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %v", err)
+	}
+	var result = make([]interface{}, 0)
+	for rows.Next() {
+		var values = make([]interface{}, len(columns))
+		var pointers = make([]interface{}, len(columns))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("failed to scan row %v: %v", len(result), err)
+		}
+		var row = make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			row[column] = values[i]
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %v", err)
+	}
+	return result, nil
+}
+
+// AssertRows validates expected against rows read as a slice of maps keyed by column name
+func AssertRows(expected interface{}, rows *sql.Rows, path assertly.DataPath, context *assertly.Context) (*assertly.Validation, error) {
+	actual, err := ReadRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	return assertly.AssertWithContext(expected, actual, path, context)
+}
+
+// AssertQuery validates expected against rows returned by supplied query
+func AssertQuery(expected interface{}, db *sql.DB, query string, path assertly.DataPath, context *assertly.Context, args ...interface{}) (*assertly.Validation, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run query: %v, %v", query, err)
+	}
+	defer rows.Close()
+	return AssertRows(expected, rows, path, context)
+}
@@ -0,0 +1,104 @@
+//go:build cgo
+// +build cgo
+
+package sql
+
+import (
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"testing"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if !assert.Nil(t, err) {
+		return nil
+	}
+	db.SetMaxOpenConns(1)
+	for _, SQL := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, active BOOLEAN, score REAL, modified DATETIME)",
+		"INSERT INTO users VALUES (1, 'Bob', 1, 3.5, '2018-01-15 08:02:23')",
+		"INSERT INTO users VALUES (2, 'Alice', 0, NULL, '2018-01-12 09:00:26')",
+	} {
+		if _, err := db.Exec(SQL); !assert.Nil(t, err, SQL) {
+			return nil
+		}
+	}
+	return db
+}
+
+func TestReadRows(t *testing.T) {
+	db := openTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	rows, err := db.Query("SELECT id, name, score FROM users ORDER BY id")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer rows.Close()
+	actual, err := ReadRows(rows)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, []interface{}{
+		map[string]interface{}{"id": int64(1), "name": "Bob", "score": 3.5},
+		map[string]interface{}{"id": int64(2), "name": "Alice", "score": nil},
+	}, actual)
+}
+
+func TestAssertQuery(t *testing.T) {
+	db := openTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	var useCases = []struct {
+		Description string
+		Expected    interface{}
+		PassedCount int
+		FailedCount int
+	}{
+		{
+			Description: "rows indexed by id",
+			Expected: `{
+	"@indexBy@": "id",
+	"2": {"name": "Alice", "score": null},
+	"1": {"name": "Bob", "active": true}
+}`,
+			PassedCount: 4,
+		},
+		{
+			Description: "rows with time layout",
+			Expected: []interface{}{
+				map[string]interface{}{"@timeFormat@modified": "yyyy-MM-dd"},
+				map[string]interface{}{"modified": "2018-01-15"},
+				map[string]interface{}{"modified": "2018-01-12"},
+			},
+			PassedCount: 2,
+		},
+		{
+			Description: "rows with cast",
+			Expected: []interface{}{
+				map[string]interface{}{"@cast@id": "string"},
+				map[string]interface{}{"id": "1"},
+				map[string]interface{}{"id": "3"},
+			},
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+	}
+	for _, useCase := range useCases {
+		validation, err := AssertQuery(useCase.Expected, db, "SELECT * FROM users WHERE id > ? ORDER BY id", assertly.NewDataPath("/"), assertly.NewDefaultContext(), 0)
+		if !assert.Nil(t, err, useCase.Description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.PassedCount, validation.PassedCount, "PassedCount "+useCase.Description)
+		assert.EqualValues(t, useCase.FailedCount, validation.FailedCount, "FailedCount "+useCase.Description)
+	}
+	_, err := AssertQuery([]interface{}{}, db, "SELECT * FROM missing", assertly.NewDataPath("/"), assertly.NewDefaultContext())
+	assert.NotNil(t, err)
+}
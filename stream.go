@@ -0,0 +1,254 @@
+package assertly
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"github.com/viant/toolbox"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// recordReader reads newline-delimited JSON records one at a time
+type recordReader struct {
+	reader *bufio.Reader
+	offset int64
+}
+
+// next returns next record with its raw line, or io.EOF when there are no more records
+func (r *recordReader) next() (interface{}, []byte, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		r.offset += int64(len(line))
+		if len(line) == 0 && err != nil {
+			return nil, nil, err
+		}
+		if err != nil && err != io.EOF {
+			return nil, nil, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		record, decodeErr := toolbox.JSONToInterface(string(line))
+		if decodeErr != nil {
+			return nil, nil, fmt.Errorf("failed to decode record at offset %v: %v", r.offset-int64(len(line)), decodeErr)
+		}
+		return record, line, nil
+	}
+}
+
+func newRecordReader(reader io.Reader) *recordReader {
+	return &recordReader{reader: bufio.NewReader(reader)}
+}
+
+// spilledRecord represents location of a record spilled to disk
+type spilledRecord struct {
+	position int
+	offset   int64
+	size     int
+}
+
+// recordIndex indexes records by key, records are spilled to a temp file, only keys and offsets are kept in memory
+type recordIndex struct {
+	file    *os.File
+	offset  int64
+	count   int
+	records map[string]*spilledRecord
+}
+
+func (i *recordIndex) put(key string, line []byte) error {
+	if _, err := i.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	i.records[key] = &spilledRecord{position: i.count, offset: i.offset, size: len(line)}
+	i.count++
+	i.offset += int64(len(line)) + 1
+	return nil
+}
+
+func (i *recordIndex) get(key string) (interface{}, bool, error) {
+	spilled, ok := i.records[key]
+	if !ok {
+		return nil, false, nil
+	}
+	var line = make([]byte, spilled.size)
+	if _, err := i.file.ReadAt(line, spilled.offset); err != nil {
+		return nil, false, err
+	}
+	record, err := toolbox.JSONToInterface(string(line))
+	return record, true, err
+}
+
+func (i *recordIndex) Close() error {
+	_ = i.file.Close()
+	return os.Remove(i.file.Name())
+}
+
+func newRecordIndex() (*recordIndex, error) {
+	file, err := ioutil.TempFile("", "assertly")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create record index: %v", err)
+	}
+	return &recordIndex{file: file, records: make(map[string]*spilledRecord)}, nil
+}
+
+// streamUnsupportedDirective returns directive that requires all records at once, thus can not be streamed
+func streamUnsupportedDirective(directive *Directive) string {
+	_, hasLength := directive.Lengths[""]
+	_, hasLengthExpression := directive.LengthExpressions[""]
+	if hasLength || hasLengthExpression {
+		return LengthDirective
+	}
+	switch {
+	case len(directive.Exprs) > 0:
+		return ExprDirective
+	case len(directive.Aggregates) > 0:
+		return directive.Aggregates[0].Function
+	case directive.Unique || len(directive.UniqueBy) > 0:
+		return UniqueDirective
+	case len(directive.SortedBy) > 0:
+		return SortedByDirective
+	case directive.SortText || len(directive.SortBy) > 0:
+		return SortByDirective
+	case len(directive.Some)+len(directive.None)+len(directive.Counts) > 0:
+		return CountDirective
+	case directive.Align:
+		return AlignDirective
+	}
+	if _, ok := directive.Schemas[""]; ok {
+		return SchemaDirective
+	}
+	if _, ok := directive.Captures[""]; ok {
+		return CaptureDirective
+	}
+	return ""
+}
+
+// AssertStream validates expected against actual newline-delimited JSON records decoded record by record,
+// records are compared by position, or by key when expected starts with @indexBy@ directive record;
+// in the latter case actual records are spilled to disk so that only index keys are kept in memory
+func AssertStream(expected, actual io.Reader, path DataPath, context *Context) (*Validation, error) {
+	validation := NewValidation()
+	expectedReader := newRecordReader(expected)
+	actualReader := newRecordReader(actual)
+	directive := path.Match(context)
+
+	first, _, err := expectedReader.next()
+	if err == io.EOF {
+		return validation, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if toolbox.IsMap(first) {
+		if firstMap := toolbox.AsMap(first); !isValueWrapper(firstMap) && directive.extractEntries(context.directiveEntries(firstMap)) {
+			if unsupported := streamUnsupportedDirective(directive); unsupported != "" {
+				return nil, fmt.Errorf("unsupported stream directive: %v, path: %v", unsupported, path.Path())
+			}
+			if first, _, err = expectedReader.next(); err == io.EOF {
+				return validation, nil
+			} else if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(directive.IndexBy) > 0 {
+		err = assertIndexedStream(first, expectedReader, actualReader, directive, path, context, validation)
+	} else {
+		err = assertPositionalStream(first, expectedReader, actualReader, directive, path, context, validation)
+	}
+	return validation, err
+}
+
+func assertPositionalStream(expectedRecord interface{}, expectedReader, actualReader *recordReader, directive *Directive, path DataPath, context *Context, validation *Validation) error {
+	var expectedCount, actualCount int
+	var err error
+	for ; err == nil; expectedRecord, _, err = expectedReader.next() {
+		expectedCount++
+		if actualCount < expectedCount-1 { //actual records were exhausted
+			continue
+		}
+		actualRecord, _, readErr := actualReader.next()
+		if readErr == io.EOF {
+			continue
+		}
+		if readErr != nil {
+			return readErr
+		}
+		actualCount++
+		expectedItems, actualItems := []interface{}{expectedRecord}, []interface{}{actualRecord}
+		if hasMapItems(expectedItems) {
+			expectedItems, actualItems = applySliceDirective(directive, expectedItems, actualItems, context)
+		}
+		if err := assertValue(expectedItems[0], actualItems[0], path.Index(expectedCount-1), context, validation); err != nil {
+			return err
+		}
+	}
+	if err != io.EOF {
+		return err
+	}
+	if actualCount < expectedCount {
+		validation.AddFailure(newFailure(path.Source(), path.Path(), LengthViolation, expectedCount, actualCount))
+	}
+	return nil
+}
+
+func assertIndexedStream(expectedRecord interface{}, expectedReader, actualReader *recordReader, directive *Directive, path DataPath, context *Context, validation *Validation) error {
+	index, err := newRecordIndex()
+	if err != nil {
+		return err
+	}
+	defer index.Close()
+	for {
+		if err := context.canceled(path); err != nil {
+			return err
+		}
+		actualRecord, line, err := actualReader.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !toolbox.IsMap(actualRecord) {
+			return fmt.Errorf("failed to index actual record %v: expected map but had %T, path: %v", index.count, actualRecord, path.Path())
+		}
+		_, actualItems := applySliceDirective(directive, nil, []interface{}{actualRecord}, context)
+		key := keysValue(toolbox.AsMap(actualItems[0]), directive.IndexBy...)
+		if spilled, ok := index.records[key]; ok {
+			itemPath := path.Index(index.count)
+			validation.AddFailure(newFailure(itemPath.Source(), itemPath.Path(), IndexKeyViolation, strings.Join(directive.IndexBy, ","), key, spilled.position, index.count))
+		}
+		if err := index.put(key, line); err != nil {
+			return fmt.Errorf("failed to index record: %v", err)
+		}
+	}
+	for ; err == nil; expectedRecord, _, err = expectedReader.next() {
+		if !toolbox.IsMap(expectedRecord) {
+			return fmt.Errorf("failed to index expected record: expected map but had %T, path: %v", expectedRecord, path.Path())
+		}
+		expectedItems, _ := applySliceDirective(directive, []interface{}{expectedRecord}, nil, context)
+		expectedMap := toolbox.AsMap(expectedItems[0])
+		key := keysValue(expectedMap, directive.IndexBy...)
+		keyPath := path.Key(keysPairValue(expectedMap, directive.IndexBy...))
+		actualRecord, ok, err := index.get(key)
+		if err != nil {
+			return fmt.Errorf("failed to read indexed record: %v", err)
+		}
+		if !ok {
+			validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), MissingEntryViolation, expectedRecord, []string{}, "key:"+key))
+			continue
+		}
+		_, actualItems := applySliceDirective(directive, nil, []interface{}{actualRecord}, context)
+		if err := assertValue(expectedMap, actualItems[0], keyPath, context, validation); err != nil {
+			return err
+		}
+	}
+	if err != io.EOF {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package assertly_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/assertly"
+	"strings"
+	"testing"
+)
+
+func TestAssertStream(t *testing.T) {
+	var useCases = []struct {
+		description string
+		expected    string
+		actual      string
+		passedCount int
+		failedCount int
+		hasError    bool
+	}{
+		{
+			description: "positional records",
+			expected:    "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n",
+			actual:      "{\"id\":1,\"name\":\"a\",\"extra\":true}\n\n{\"id\":2,\"name\":\"b\"}",
+			passedCount: 4,
+		},
+		{
+			description: "positional mismatch",
+			expected:    "{\"id\":1}\n{\"id\":2}",
+			actual:      "{\"id\":1}\n{\"id\":3}",
+			passedCount: 1,
+			failedCount: 1,
+		},
+		{
+			description: "missing actual records",
+			expected:    "{\"id\":1}\n{\"id\":2}\n{\"id\":3}",
+			actual:      "{\"id\":1}",
+			passedCount: 1,
+			failedCount: 1,
+		},
+		{
+			description: "record directives",
+			expected:    "{\"@cast@id\":\"int\",\"@timeFormat@ts\":\"yyyy-MM-dd\"}\n{\"id\":1,\"ts\":\"2018-01-15\"}",
+			actual:      "{\"id\":\"1\",\"ts\":\"2018-01-15\"}",
+			passedCount: 2,
+		},
+		{
+			description: "indexed records",
+			expected:    "{\"@indexBy@\":\"id\"}\n{\"id\":2,\"name\":\"b\"}\n{\"id\":1,\"name\":\"a\"}",
+			actual:      "{\"id\":1,\"name\":\"a\"}\n{\"id\":3,\"name\":\"c\"}\n{\"id\":2,\"name\":\"b\"}",
+			passedCount: 4,
+		},
+		{
+			description: "indexed missing and duplicated records",
+			expected:    "{\"@indexBy@\":\"id\"}\n{\"id\":1,\"name\":\"a\"}\n{\"id\":4,\"name\":\"d\"}",
+			actual:      "{\"id\":1,\"name\":\"x\"}\n{\"id\":1,\"name\":\"a\"}",
+			passedCount: 2,
+			failedCount: 2,
+		},
+		{
+			description: "unsupported directive",
+			expected:    "{\"@unique@\":true}\n{\"id\":1}",
+			actual:      "{\"id\":1}",
+			hasError:    true,
+		},
+		{
+			description: "invalid record",
+			expected:    "{\"id\":1}\n{\"id\":",
+			actual:      "{\"id\":1}\n{\"id\":2}",
+			hasError:    true,
+		},
+	}
+	for _, useCase := range useCases {
+		validation, err := assertly.AssertStream(strings.NewReader(useCase.expected), strings.NewReader(useCase.actual), assertly.NewDataPath("/"), assertly.NewDefaultContext())
+		if useCase.hasError {
+			assert.NotNil(t, err, useCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.passedCount, validation.PassedCount, "PassedCount "+useCase.description)
+		assert.EqualValues(t, useCase.failedCount, validation.FailedCount, "FailedCount "+useCase.description+" "+validation.Report())
+	}
+}
@@ -0,0 +1,234 @@
+package assertly
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	diffContextLines = 3
+	maxDiffCells     = 4 * 1024 * 1024
+)
+
+const (
+	DiffEqual  = ' '
+	DiffDelete = '-'
+	DiffInsert = '+'
+)
+
+// DiffLine represents unified diff line
+type DiffLine struct {
+	Op   byte
+	Text string
+}
+
+// DiffHunk represents unified diff hunk, line numbers start with 1
+type DiffHunk struct {
+	ExpectedLine  int
+	ExpectedCount int
+	ActualLine    int
+	ActualCount   int
+	Lines         []*DiffLine
+}
+
+// TextDiff represents line level difference between expected and actual text
+type TextDiff struct {
+	Line   int
+	Column int
+	Hunks  []*DiffHunk
+}
+
+// diffEdit represents an edit script operation, for equal and delete Expected is expected index, for equal and insert Actual is actual index
+type diffEdit struct {
+	Op       byte
+	Expected int
+	Actual   int
+}
+
+// diffIndexes returns edit script transforming expected into actual items using longest common subsequence,
+// common prefix and suffix are matched directly, if remaining items exceed maxDiffCells they are replaced as a whole
+func diffIndexes(expectedLen, actualLen int, equal func(i, j int) bool) []*diffEdit {
+	var prefix = 0
+	for prefix < expectedLen && prefix < actualLen && equal(prefix, prefix) {
+		prefix++
+	}
+	var suffix = 0
+	for suffix < expectedLen-prefix && suffix < actualLen-prefix && equal(expectedLen-suffix-1, actualLen-suffix-1) {
+		suffix++
+	}
+	var result = make([]*diffEdit, 0, expectedLen+actualLen)
+	for i := 0; i < prefix; i++ {
+		result = append(result, &diffEdit{Op: DiffEqual, Expected: i, Actual: i})
+	}
+	n, m := expectedLen-prefix-suffix, actualLen-prefix-suffix
+	if n*m > maxDiffCells {
+		for i := 0; i < n; i++ {
+			result = append(result, &diffEdit{Op: DiffDelete, Expected: prefix + i, Actual: -1})
+		}
+		for j := 0; j < m; j++ {
+			result = append(result, &diffEdit{Op: DiffInsert, Expected: -1, Actual: prefix + j})
+		}
+	} else {
+		lengths := make([][]int, n+1)
+		for i := range lengths {
+			lengths[i] = make([]int, m+1)
+		}
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if equal(prefix+i, prefix+j) {
+					lengths[i][j] = lengths[i+1][j+1] + 1
+				} else if lengths[i+1][j] >= lengths[i][j+1] {
+					lengths[i][j] = lengths[i+1][j]
+				} else {
+					lengths[i][j] = lengths[i][j+1]
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && equal(prefix+i, prefix+j):
+				result = append(result, &diffEdit{Op: DiffEqual, Expected: prefix + i, Actual: prefix + j})
+				i++
+				j++
+			case j == m || (i < n && lengths[i+1][j] >= lengths[i][j+1]):
+				result = append(result, &diffEdit{Op: DiffDelete, Expected: prefix + i, Actual: -1})
+				i++
+			default:
+				result = append(result, &diffEdit{Op: DiffInsert, Expected: -1, Actual: prefix + j})
+				j++
+			}
+		}
+	}
+	for i := suffix; i > 0; i-- {
+		result = append(result, &diffEdit{Op: DiffEqual, Expected: expectedLen - i, Actual: actualLen - i})
+	}
+	return result
+}
+
+// isMultiLineText returns true if any of supplied texts has more than one line
+func isMultiLineText(texts ...string) bool {
+	for _, text := range texts {
+		if strings.Contains(strings.TrimRight(text, "\n"), "\n") {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTextDiff returns line level diff between expected and actual text or nil if texts are equal
+func NewTextDiff(expected, actual string) *TextDiff {
+	if expected == actual {
+		return nil
+	}
+	expectedLines, actualLines := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	edits := diffIndexes(len(expectedLines), len(actualLines), func(i, j int) bool {
+		return expectedLines[i] == actualLines[j]
+	})
+	var result = &TextDiff{Hunks: make([]*DiffHunk, 0)}
+	for k, edit := range edits {
+		if edit.Op == DiffEqual {
+			continue
+		}
+		var expectedLine, actualLine string
+		for _, change := range edits[k:] {
+			if change.Op == DiffEqual {
+				break
+			}
+			if change.Op == DiffDelete && expectedLine == "" {
+				expectedLine = expectedLines[change.Expected]
+			} else if change.Op == DiffInsert && actualLine == "" {
+				actualLine = actualLines[change.Actual]
+			}
+		}
+		result.Line = lineNumber(edits, k, true)
+		result.Column = firstDifferentColumn(expectedLine, actualLine)
+		break
+	}
+	for k := 0; k < len(edits); {
+		if edits[k].Op == DiffEqual {
+			k++
+			continue
+		}
+		start, last := k-diffContextLines, k
+		if start < 0 {
+			start = 0
+		}
+		for j := k + 1; j < len(edits) && j-last <= 2*diffContextLines; j++ {
+			if edits[j].Op != DiffEqual {
+				last = j
+			}
+		}
+		end := last + diffContextLines + 1
+		if end > len(edits) {
+			end = len(edits)
+		}
+		hunk := &DiffHunk{ExpectedLine: lineNumber(edits, start, true), ActualLine: lineNumber(edits, start, false)}
+		hunk.addEdits(edits[start:end], expectedLines, actualLines)
+		result.Hunks = append(result.Hunks, hunk)
+		k = end
+	}
+	return result
+}
+
+// lineNumber returns 1 based expected or actual line number of the edit at supplied position
+func lineNumber(edits []*diffEdit, position int, expected bool) int {
+	var result = 1
+	for _, edit := range edits[:position] {
+		if expected && edit.Op != DiffInsert {
+			result++
+		} else if !expected && edit.Op != DiffDelete {
+			result++
+		}
+	}
+	return result
+}
+
+func (h *DiffHunk) addEdits(edits []*diffEdit, expectedLines, actualLines []string) {
+	for _, edit := range edits {
+		switch edit.Op {
+		case DiffEqual:
+			h.Lines = append(h.Lines, &DiffLine{Op: DiffEqual, Text: expectedLines[edit.Expected]})
+			h.ExpectedCount++
+			h.ActualCount++
+		case DiffDelete:
+			h.Lines = append(h.Lines, &DiffLine{Op: DiffDelete, Text: expectedLines[edit.Expected]})
+			h.ExpectedCount++
+		case DiffInsert:
+			h.Lines = append(h.Lines, &DiffLine{Op: DiffInsert, Text: actualLines[edit.Actual]})
+			h.ActualCount++
+		}
+	}
+}
+
+// firstDifferentColumn returns 1 based column of the first different character
+func firstDifferentColumn(expected, actual string) int {
+	expectedRunes, actualRunes := []rune(expected), []rune(actual)
+	var i = 0
+	for i < len(expectedRunes) && i < len(actualRunes) && expectedRunes[i] == actualRunes[i] {
+		i++
+	}
+	return i + 1
+}
+
+// Unified returns unified diff representation
+func (d *TextDiff) Unified() string {
+	var builder = new(strings.Builder)
+	builder.WriteString("--- expected\n+++ actual")
+	for _, hunk := range d.Hunks {
+		builder.WriteString(fmt.Sprintf("\n@@ -%v +%v @@", hunkRange(hunk.ExpectedLine, hunk.ExpectedCount), hunkRange(hunk.ActualLine, hunk.ActualCount)))
+		for _, line := range hunk.Lines {
+			builder.WriteString("\n")
+			builder.WriteByte(line.Op)
+			builder.WriteString(line.Text)
+		}
+	}
+	return builder.String()
+}
+
+func hunkRange(line, count int) string {
+	if count == 0 {
+		line--
+	}
+	return fmt.Sprintf("%v,%v", line, count)
+}
@@ -0,0 +1,71 @@
+package assertly
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTextDiff(t *testing.T) {
+	var useCases = []struct {
+		description string
+		expected    string
+		actual      string
+		line        int
+		column      int
+		unified     string
+	}{
+		{
+			description: "changed line",
+			expected:    "select id,\n  name\nfrom users\nwhere id = 1",
+			actual:      "select id,\n  name\nfrom user\nwhere id = 1",
+			line:        3,
+			column:      10,
+			unified:     "--- expected\n+++ actual\n@@ -1,4 +1,4 @@\n select id,\n   name\n-from users\n+from user\n where id = 1",
+		},
+		{
+			description: "inserted line",
+			expected:    "a\nb\nc",
+			actual:      "a\nb\nx\nc",
+			line:        3,
+			column:      1,
+			unified:     "--- expected\n+++ actual\n@@ -1,3 +1,4 @@\n a\n b\n+x\n c",
+		},
+		{
+			description: "deleted line",
+			expected:    "a\nb\nc",
+			actual:      "a\nc",
+			line:        2,
+			column:      1,
+			unified:     "--- expected\n+++ actual\n@@ -1,3 +1,2 @@\n a\n-b\n c",
+		},
+		{
+			description: "separate hunks",
+			expected:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12",
+			actual:      "0\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n13",
+			line:        1,
+			column:      1,
+			unified:     "--- expected\n+++ actual\n@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+13",
+		},
+	}
+	for _, useCase := range useCases {
+		diff := NewTextDiff(useCase.expected, useCase.actual)
+		if !assert.NotNil(t, diff, useCase.description) {
+			continue
+		}
+		assert.EqualValues(t, useCase.line, diff.Line, useCase.description)
+		assert.EqualValues(t, useCase.column, diff.Column, useCase.description)
+		assert.EqualValues(t, useCase.unified, diff.Unified(), useCase.description)
+	}
+	assert.Nil(t, NewTextDiff("a\nb", "a\nb"))
+}
+
+func TestFormatMessage_TextDiff(t *testing.T) {
+	validation := NewValidation()
+	err := assertText("line 1\nline 2", "line 1\nline 3", NewDataPath("/"), NewDefaultContext(), validation)
+	assert.Nil(t, err)
+	if assert.EqualValues(t, 1, validation.FailedCount) {
+		failure := validation.Failures[0]
+		assert.EqualValues(t, EqualViolation, failure.Reason)
+		assert.EqualValues(t, "actual text was not equal expected at line 2, column 6:\n--- expected\n+++ actual\n@@ -1,2 +1,2 @@\n line 1\n-line 2\n+line 3", failure.Message)
+	}
+}
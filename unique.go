@@ -0,0 +1,79 @@
+package assertly
+
+import (
+	"encoding/json"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"strings"
+)
+
+// duplicateItem represents an item sharing key with an earlier item
+type duplicateItem struct {
+	key       string
+	index     int
+	duplicate int
+}
+
+// findDuplicates returns every item sharing key with an earlier item
+func findDuplicates(aSlice []interface{}, keyOf func(item interface{}) string) []*duplicateItem {
+	var result = make([]*duplicateItem, 0)
+	var indexes = make(map[string]int)
+	for i, item := range aSlice {
+		key := keyOf(item)
+		if index, ok := indexes[key]; ok {
+			result = append(result, &duplicateItem{key: key, index: index, duplicate: i})
+			continue
+		}
+		indexes[key] = i
+	}
+	return result
+}
+
+// uniqueKey returns item identity for supplied fields or the whole item if no fields are specified
+func uniqueKey(fields []string) func(item interface{}) string {
+	return func(item interface{}) string {
+		var value = item
+		if len(fields) > 0 {
+			var values = make([]interface{}, len(fields))
+			if item != nil && toolbox.IsMap(item) {
+				aMap := data.Map(toolbox.AsMap(item))
+				for i, field := range fields {
+					values[i], _ = aMap.GetValue(field)
+				}
+			}
+			value = values
+		}
+		if encoded, err := json.Marshal(value); err == nil {
+			return string(encoded)
+		}
+		return toolbox.AsString(value)
+	}
+}
+
+func assertUnique(fields []string, actual []interface{}, path DataPath, validation *Validation) {
+	keyOf := uniqueKey(fields)
+	duplicates := findDuplicates(actual, keyOf)
+	if len(duplicates) == 0 {
+		validation.PassedCount++
+		return
+	}
+	var expected = "item"
+	if len(fields) > 0 {
+		expected = strings.Join(fields, ",")
+	}
+	for _, duplicate := range duplicates {
+		itemPath := path.Index(duplicate.duplicate)
+		validation.AddFailure(newFailure(itemPath.Source(), itemPath.Path(), UniqueViolation, expected, duplicate.key, duplicate.index, duplicate.duplicate))
+	}
+}
+
+// assertIndexKeys reports actual items that would be collapsed by index key
+func assertIndexKeys(indexBy []string, actual []interface{}, path DataPath, validation *Validation) {
+	duplicates := findDuplicates(actual, func(item interface{}) string {
+		return keysValue(toolbox.AsMap(item), indexBy...)
+	})
+	for _, duplicate := range duplicates {
+		itemPath := path.Index(duplicate.duplicate)
+		validation.AddFailure(newFailure(itemPath.Source(), itemPath.Path(), IndexKeyViolation, strings.Join(indexBy, ","), duplicate.key, duplicate.index, duplicate.duplicate))
+	}
+}
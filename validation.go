@@ -12,13 +12,18 @@ type Validation struct {
 	PassedCount int
 	FailedCount int
 	Failures    []*Failure
+	trial       bool
+	captures    []*capture
 }
 
-//AddFailure add failure to current violation
+//AddFailure add failure to current violation, failure is rendered unless validation is used for candidate matching
 func (v *Validation) AddFailure(failure *Failure) {
 	if len(v.Failures) == 0 {
 		v.Failures = make([]*Failure, 0)
 	}
+	if !v.trial {
+		failure.render()
+	}
 	v.Failures = append(v.Failures, failure)
 	v.FailedCount++
 }
@@ -34,6 +39,23 @@ func (v *Validation) MergeFrom(source *Validation) {
 	for _, failure := range source.Failures {
 		v.AddFailure(failure)
 	}
+	v.mergeCaptures(source)
+}
+
+//addCapture stores captured value, capture is buffered if validation is used for candidate matching
+func (v *Validation) addCapture(capture *capture) {
+	if v.trial {
+		v.captures = append(v.captures, capture)
+		return
+	}
+	capture.apply()
+}
+
+//mergeCaptures adds captures buffered by source validation in capture order
+func (v *Validation) mergeCaptures(source *Validation) {
+	for _, capture := range source.captures {
+		v.addCapture(capture)
+	}
 }
 
 //Report returns validation report
@@ -53,3 +75,11 @@ func NewValidation() *Validation {
 		Failures: make([]*Failure, 0),
 	}
 }
+
+//newTrialValidation returns validation for candidate matching, its failures are rendered only once merged into reported validation
+func newTrialValidation() *Validation {
+	return &Validation{
+		Failures: make([]*Failure, 0),
+		trial:    true,
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"path"
 	"reflect"
 	"regexp"
-	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -36,6 +35,19 @@ const (
 	ValueWasNil                   = "should have not nil"
 	SharedSwitchCaseKey           = "shared"
 	ElapseRangeViolation          = "should elapsed be within"
+	TypeViolation                 = "should have data type"
+	SchemaViolation               = "should match schema"
+	SomeViolation                 = "at least one item should match"
+	NoneViolation                 = "no item should match"
+	CountViolation                = "should have matching items count"
+	UniqueViolation               = "should be unique"
+	IndexKeyViolation             = "should have unique index key"
+	SortedViolation               = "should be sorted"
+	OneOfViolation                = "should match exactly one candidate"
+	AnyOfViolation                = "should match any candidate"
+	KeyCountViolation             = "should have matching keys count"
+	UnexpectedItemViolation       = "item was unexpected"
+	DecodeViolation               = "should be decodable"
 )
 
 // Assert validates expected against actual data structure for supplied path
@@ -74,7 +86,10 @@ func expandExpectedText(text string, path DataPath, context *Context) (interface
 		return asDataStructure(text), nil
 	}
 	if context.Evaluator.HasMacro(text) {
-		evaluated, err := context.Evaluator.Expand(context.Context, text)
+		evaluated, err := context.expand(text, path)
+		if _, canceled := err.(*CanceledError); canceled {
+			return nil, err
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to expand macro %v, path:%v, %v", text, path.Path(), err)
 		}
@@ -96,11 +111,11 @@ func assertTime(expected *time.Time, actual interface{}, path DataPath, context
 				validation.PassedCount++
 				return nil
 			}
-			validation.AddFailure(NewFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
+			validation.AddFailure(newFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
 		}
 
 		if actualTime == nil {
-			validation.AddFailure(NewFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
+			validation.AddFailure(newFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
 			return nil
 		}
 
@@ -123,11 +138,21 @@ func assertTime(expected *time.Time, actual interface{}, path DataPath, context
 		}
 
 	}
-	validation.AddFailure(NewFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
+	validation.AddFailure(newFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
 	return nil
 }
 
 func assertValue(expected, actual interface{}, path DataPath, context *Context, validation *Validation) (err error) {
+	if err := context.canceled(path); err != nil {
+		return err
+	}
+	if value, optional, nullable := unwrapMarkers(expected); optional || nullable {
+		if nullable && actual == nil {
+			validation.PassedCount++
+			return nil
+		}
+		expected = value
+	}
 
 	directive := NewDirective(path)
 	if expected == nil {
@@ -136,11 +161,16 @@ func assertValue(expected, actual interface{}, path DataPath, context *Context,
 			return nil
 		}
 		if !directive.StrictMapCheck {
-			validation.AddFailure(NewFailure(path.Source(), path.Path(), NotEqualViolation, expected, actual))
+			validation.AddFailure(newFailure(path.Source(), path.Path(), NotEqualViolation, expected, actual))
 			return
 		}
 	}
 
+	if context.NoImplicitCasting && !isDataTypeCompatible(expected, actual, context) {
+		validation.AddFailure(newFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actual))
+		return nil
+	}
+
 	switch val := expected.(type) {
 	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
 		assertInt(expected, actual, path, context, validation)
@@ -160,6 +190,10 @@ func assertValue(expected, actual interface{}, path DataPath, context *Context,
 		}
 	}
 
+	if alternatives, candidates, ok := alternativesOf(expected); ok {
+		return assertAlternatives(alternatives, candidates, actual, path, context, validation)
+	}
+
 	predicate := getPredicate(expected)
 	if predicate == nil {
 		switch val := actual.(type) {
@@ -187,8 +221,13 @@ func assertValue(expected, actual interface{}, path DataPath, context *Context,
 		}
 	} else {
 
+		if capture, ok := predicate.(*capturePredicate); ok {
+			validation.addCapture(capture.capture(actual))
+			validation.PassedCount++
+			return nil
+		}
 		if !predicate.Apply(actual) {
-			validation.AddFailure(NewFailure(path.Source(), path.Path(), PredicateViolation, fmt.Sprintf("%T%v", predicate, predicate), actual))
+			validation.AddFailure(newFailure(path.Source(), path.Path(), PredicateViolation, fmt.Sprintf("%T%v", predicate, predicate), actual))
 		} else {
 			validation.PassedCount++
 		}
@@ -271,9 +310,9 @@ func assertRegExpr(isNegated bool, expected, actual string, path DataPath, conte
 	}
 	var matches = compiled.Match(([]byte)(actual))
 	if !matches && !isNegated {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), RegExprMatchesViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), RegExprMatchesViolation, expected, actual))
 	} else if matches && isNegated {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), RegExprDoesNotMatchViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), RegExprDoesNotMatchViolation, expected, actual))
 	} else {
 		validation.PassedCount++
 	}
@@ -304,9 +343,9 @@ func assertRange(isNegated bool, expected, actual string, path DataPath, context
 		}
 	}
 	if !withinRange && !isNegated {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), RangeViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), RangeViolation, expected, actual))
 	} else if withinRange && isNegated {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), RangeNotViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), RangeNotViolation, expected, actual))
 	} else {
 		validation.PassedCount++
 	}
@@ -318,9 +357,9 @@ func assertContains(isNegated bool, expected, actual string, path DataPath, cont
 	contains := strings.Contains(actual, expected)
 
 	if !contains && !isNegated {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), ContainsViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), ContainsViolation, expected, actual))
 	} else if contains && isNegated {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), DoesNotContainViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), DoesNotContainViolation, expected, actual))
 	} else {
 		validation.PassedCount++
 	}
@@ -333,6 +372,17 @@ func assertText(expected, actual string, path DataPath, context *Context, valida
 		expected = strings.ToLower(expected)
 		actual = strings.ToLower(actual)
 	}
+	if directive != nil && len(directive.Normalize) > 0 {
+		var err error
+		if actual, err = normalizeText(actual, directive.Normalize); err != nil {
+			return fmt.Errorf("%v, path: %v", err, path.Path())
+		}
+		if pattern, _ := isNegated(strings.TrimSpace(expected)); !strings.HasPrefix(pattern, "~/") {
+			if expected, err = normalizeText(expected, directive.Normalize); err != nil {
+				return fmt.Errorf("%v, path: %v", err, path.Path())
+			}
+		}
+	}
 
 	expected = strings.TrimSpace(expected)
 	if strings.HasSuffix(expected, "/") {
@@ -355,9 +405,13 @@ func assertText(expected, actual string, path DataPath, context *Context, valida
 	isEqual := expected == actual
 
 	if !isEqual && !isNegated {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
+		if isMultiLineText(expected, actual) {
+			validation.AddFailure(newFailure(path.Source(), path.Path(), EqualViolation, expected, actual, NewTextDiff(expected, actual)))
+			return nil
+		}
+		validation.AddFailure(newFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
 	} else if isEqual && isNegated {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), NotEqualViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), NotEqualViolation, expected, actual))
 	} else {
 		validation.PassedCount++
 	}
@@ -370,13 +424,14 @@ func actualMap(expected, actualValue interface{}, path DataPath, directive *Dire
 		actual = toolbox.AsMap(actualValue)
 	} else if toolbox.IsSlice(actualValue) {
 		if len(directive.IndexBy) == 0 {
-			validation.AddFailure(NewFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actualValue))
+			validation.AddFailure(newFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actualValue))
 			return nil
 		}
 		aSlice := toolbox.AsSlice(actualValue)
+		assertIndexKeys(directive.IndexBy, aSlice, path, validation)
 		actual = indexSliceBy(aSlice, directive.IndexBy...)
 	} else {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actualValue))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actualValue))
 		return nil
 	}
 	return actual
@@ -412,7 +467,7 @@ func assertInt(expected, actual interface{}, path DataPath, context *Context, va
 				return
 			}
 		}
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
 	} else {
 		validation.PassedCount++
 	}
@@ -427,9 +482,10 @@ func assertFloat(expected, actual interface{}, path DataPath, context *Context,
 		expected = 0
 	}
 
-	if actualFloat, ok := actual.(float64); ok && directive.NumericPrecisionPoint == nil {
-		if isEqual := expectedErr == nil && expectedFloat == actualFloat; !isEqual {
-			validation.AddFailure(NewFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
+	//exact float64 shortcut applies to numeric expectation only, expressions like /[1..3]/ or !2 are evaluated below
+	if actualFloat, ok := actual.(float64); ok && directive.NumericPrecisionPoint == nil && expectedErr == nil {
+		if expectedFloat != actualFloat {
+			validation.AddFailure(newFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
 			return
 		}
 	}
@@ -467,12 +523,58 @@ func assertFloat(expected, actual interface{}, path DataPath, context *Context,
 		if actualErr == nil && float64(int(actualFloat)) == actualFloat {
 			actual = int(actualFloat)
 		}
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), EqualViolation, expected, actual))
 	} else {
 		validation.PassedCount++
 	}
 }
 
+func lengthOf(value interface{}) int {
+	if value == nil {
+		return 0
+	}
+	if text, ok := value.(string); ok {
+		return len(text)
+	}
+	if toolbox.IsSlice(value) {
+		return len(toolbox.AsSlice(value))
+	} else if toolbox.IsMap(value) {
+		return len(toolbox.AsMap(value))
+	}
+	return 0
+}
+
+func assertLength(expected, actual interface{}, path DataPath, validation *Validation) error {
+	actualLength := lengthOf(actual)
+	isValid, err := matchCount(expected, actualLength)
+	if err != nil {
+		return fmt.Errorf("invalid length expression, %v, path: %v", err, path.Path())
+	}
+	if isValid {
+		validation.PassedCount++
+		return nil
+	}
+	validation.AddFailure(newFailure(path.Source(), path.Path(), LengthViolation, expected, actualLength))
+	return nil
+}
+
+func assertDataTypes(types map[string]string, actual map[string]interface{}, path DataPath, validation *Validation) {
+	actualMap := data.Map(actual)
+	for key, expectedType := range types {
+		keyPath := path.Key(key)
+		value, ok := actualMap.GetValue(key)
+		if !ok {
+			validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), TypeViolation, expectedType, nil, "missing"))
+			continue
+		}
+		if matchDataType(expectedType, value) {
+			validation.PassedCount++
+			continue
+		}
+		validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), TypeViolation, expectedType, value, dataTypeOf(value)))
+	}
+}
+
 func assertPathIfNeeded(directive *Directive, path DataPath, context *Context, validation *Validation, actual map[string]interface{}) error {
 	if len(directive.AssertPaths) > 0 {
 		actualMap := data.Map(actual)
@@ -483,7 +585,7 @@ func assertPathIfNeeded(directive *Directive, path DataPath, context *Context, v
 				if assertPath.Expected == KeyDoesNotExistsDirective {
 					validation.PassedCount++
 				} else {
-					validation.AddFailure(NewFailure(path.Source(), keyPath.Path(), KeyExistsViolation, assertPath.Expected, actual))
+					validation.AddFailure(newFailure(path.Source(), keyPath.Path(), KeyExistsViolation, assertPath.Expected, actual))
 				}
 				continue
 			}
@@ -502,13 +604,13 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 			validation.PassedCount++
 			return nil
 		}
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), ValueWasNil, nil, expected))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), ValueWasNil, nil, expected))
 		return nil
 	}
 
 	directive := NewDirective(path)
 	directive.mergeFrom(path.Match(context))
-	directive.ExtractDirectives(expected)
+	directive.extractEntries(context.directiveEntries(expected))
 
 	path.SetSource(directive.Source)
 
@@ -516,20 +618,47 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 	if actual == nil {
 		return nil
 	}
+	var undecoded map[string]bool
+	if len(directive.Decodings) > 0 {
+		undecoded = decodeValues(directive.Decodings, actual, path, validation)
+	}
 
 	if err := assertPathIfNeeded(directive, path, context, validation, actual); err != nil {
 		return err
 	}
-	directive.ExtractDataTypes(actual)
+	if len(directive.Types) > 0 {
+		assertDataTypes(directive.Types, actual, path, validation)
+	}
+	if len(directive.Schemas) > 0 {
+		if err := assertSchemas(directive.Schemas, actualValue, actual, path, validation); err != nil {
+			return err
+		}
+	}
+	if len(directive.Captures) > 0 {
+		captureValues(directive.Captures, actualValue, actual, path, context, validation)
+	}
+	if !context.NoImplicitCasting {
+		directive.ExtractDataTypes(actual)
+	}
 	if err := directive.Apply(actual); err != nil {
 		log.Print("failed to apply directive to actual actual value: " + err.Error())
 	}
+	if len(directive.Exprs) > 0 {
+		if err := assertExpressions(directive.Exprs, actual, path, context, validation); err != nil {
+			return err
+		}
+	}
+	if len(directive.Aggregates) > 0 {
+		if err := assertAggregates(directive.Aggregates, actual, path, context, validation); err != nil {
+			return err
+		}
+	}
 
 	if len(directive.SwitchBy) > 0 {
 		switchValue := keysValue(actual, directive.SwitchBy...)
 		caseValue, ok := expected[switchValue]
 		if !ok {
-			validation.AddFailure(NewFailure(path.Source(), path.Path(), MissingCaseViolation, expected, actual, directive.SwitchBy, switchValue))
+			validation.AddFailure(newFailure(path.Source(), path.Path(), MissingCaseViolation, expected, actual, directive.SwitchBy, switchValue))
 			return nil
 		}
 		if !toolbox.IsMap(caseValue) {
@@ -542,9 +671,19 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 				caseValueMap[k] = v
 			}
 		}
+		for k, v := range expected {
+			if isConditionalKey(k) {
+				caseValueMap[k] = v
+			}
+		}
 		expected = caseValueMap
 	}
 
+	expected, err := applyConditions(expected, actual, path, context, validation)
+	if err != nil {
+		return err
+	}
+
 	if err := directive.Apply(expected); err != nil {
 		log.Print("failed to apply directive to expected value:" + err.Error())
 	}
@@ -566,7 +705,7 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 				}
 				diff := time.Now().Sub(*actualTime)
 				if diff < from || diff > to {
-					validation.AddFailure(NewFailure(path.Source(), path.Path(), ElapseRangeViolation, fmt.Sprintf("%v..%v", int(from.Seconds()), int(to.Seconds())), int(diff.Seconds())))
+					validation.AddFailure(newFailure(path.Source(), path.Path(), ElapseRangeViolation, fmt.Sprintf("%v..%v", int(from.Seconds()), int(to.Seconds())), int(diff.Seconds())))
 				} else {
 					validation.PassedCount++
 				}
@@ -577,44 +716,44 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 		directive.ElaspedRange = make(map[string]string)
 	}
 
-	if len(directive.Lengths) > 0 {
-		for key, expectedLength := range directive.Lengths {
+	if len(directive.Lengths) > 0 || len(directive.LengthExpressions) > 0 {
+		for key, expectedLength := range directive.expectedLengths() {
+			if key == "" {
+				if err := assertLength(expectedLength, actual, path, validation); err != nil {
+					return err
+				}
+				continue
+			}
 			aMap := data.Map(actual)
 			value, ok := aMap.GetValue(key)
 			keyPath := path.Key(key)
 			if !ok {
-				validation.AddFailure(NewFailure(keyPath.Source(), keyPath.Path(), LengthViolation, expectedLength, value))
+				validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), LengthViolation, expectedLength, value))
 				continue
 			}
-			actualLength := 0
-			if value != nil {
-				if toolbox.IsSlice(value) {
-					actualLength = len(toolbox.AsSlice(value))
-				} else if toolbox.IsMap(value) {
-					actualLength = len(toolbox.AsMap(value))
-				}
-				if text, ok := value.(string); ok {
-					actualLength = len(text)
-				}
-			}
-			if actualLength == expectedLength {
-				validation.PassedCount++
-				continue
+			if err := assertLength(expectedLength, value, keyPath, validation); err != nil {
+				return err
 			}
-			validation.AddFailure(NewFailure(keyPath.Source(), keyPath.Path(), LengthViolation, expectedLength, actualLength))
 		}
 	}
-	var checkedKeys map[string]bool
+	patternKeys, err := assertKeyPatterns(expected, actual, directive, path, context, validation)
+	if err != nil {
+		return err
+	}
+	var checkedKeys = expected
 	if directive.StrictMapCheck {
-		checkedKeys = getKeys(expected, actual)
-	} else {
-		checkedKeys = getKeys(expected)
+		checkedKeys = make(map[string]interface{}, len(expected)+len(actual))
+		for key := range getKeys(expected, actual) {
+			checkedKeys[key] = expected[key]
+		}
 	}
 
+	var assertions []*valueAssertion
+	workers := context.workers(len(checkedKeys))
 	for expectedKey := range checkedKeys {
 		expectedValue := expected[expectedKey]
 
-		if directive.IsDirectiveKey(expectedKey) {
+		if directive.IsDirectiveKey(expectedKey) || patternKeys[expectedKey] || undecoded[expectedKey] {
 			continue
 		}
 		var keyPath DataPath
@@ -624,9 +763,14 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 			keyPath = path.Key(expectedKey)
 		}
 		actualValue, ok := actual[expectedKey]
+		_, optional, _ := unwrapMarkers(expectedValue)
+		if (!ok && (optional || directive.Optional[expectedKey])) || (ok && actualValue == nil && directive.Nullable[expectedKey]) {
+			validation.PassedCount++
+			continue
+		}
 		if directive.KeyDoesNotExist[expectedKey] {
 			if ok {
-				validation.AddFailure(NewFailure(keyPath.Source(), keyPath.Path(), KeyDoesNotExistViolation, expectedKey, expectedKey))
+				validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), KeyDoesNotExistViolation, expectedKey, expectedKey))
 			} else {
 				validation.PassedCount++
 			}
@@ -636,7 +780,7 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 		if directive.KeyExists[expectedKey] {
 			if !ok {
 				availableKeys := toolbox.MapKeysToStringSlice(expected)
-				validation.AddFailure(NewFailure(keyPath.Source(), keyPath.Path(), KeyExistsViolation, expectedKey, strings.Join(availableKeys, ",")))
+				validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), KeyExistsViolation, expectedKey, strings.Join(availableKeys, ",")))
 			} else {
 				validation.PassedCount++
 			}
@@ -649,13 +793,21 @@ func assertMap(expected map[string]interface{}, actualValue interface{}, path Da
 			if len(available) > 32 {
 				available = append(available[0:16], "...")
 			}
-			validation.AddFailure(NewFailure(keyPath.Source(), keyPath.Path(), MissingEntryViolation, expectedValue, available, key))
+			validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), MissingEntryViolation, expectedValue, available, key))
+			continue
+		}
+		if workers > 1 {
+			assertions = append(assertions, &valueAssertion{expected: expectedValue, actual: actualValue, path: isolatePath(keyPath)})
 			continue
 		}
 		if err := assertValue(expectedValue, actualValue, keyPath, context, validation); err != nil {
 			return err
 		}
 	}
+	if len(assertions) > 0 {
+		sortAssertions(assertions)
+		return assertConcurrently(assertions, workers, context, validation)
+	}
 	return nil
 }
 
@@ -724,9 +876,66 @@ func asValueCaseInsensitiveSlice(aSlice []interface{}) []interface{} {
 	return result
 }
 
+// hasMapItems returns true if the first non nil item is a map or struct
+func hasMapItems(aSlice []interface{}) bool {
+	for _, item := range aSlice {
+		if item != nil {
+			return toolbox.IsMap(item) || toolbox.IsStruct(item)
+		}
+	}
+	return false
+}
+
+// applySliceDirective applies slice directive to expected and actual map items
+func applySliceDirective(directive *Directive, expected, actual []interface{}, context *Context) ([]interface{}, []interface{}) {
+	if !directive.KeyCaseSensitive {
+		expected = asKeyCaseInsensitiveSlice(expected)
+		actual = asKeyCaseInsensitiveSlice(actual)
+		directive.ApplyKeyCaseInsensitive()
+	}
+
+	if !directive.CaseSensitive {
+		expected = asValueCaseInsensitiveSlice(expected)
+		actual = asValueCaseInsensitiveSlice(actual)
+	}
+
+	for i := 0; i < len(actual); i++ { //convert struct items once
+		if actual[i] != nil && !toolbox.IsMap(actual[i]) {
+			actual[i] = toolbox.AsMap(actual[i])
+		}
+	}
+	if !context.NoImplicitCasting {
+		for i := 0; i < len(actual); i++ {
+			if actualMap, ok := actual[i].(map[string]interface{}); ok {
+				directive.ExtractDataTypes(actualMap)
+			}
+		}
+	}
+
+	//add directive to expected
+	for i := 0; i < len(expected); i++ {
+		if expected[i] == nil {
+			if i < len(actual) && actual[i] == nil {
+				expected[i] = map[string]interface{}{}
+				actual[i] = map[string]interface{}{}
+			}
+		}
+		var expectedMap = toolbox.AsMap(expected[i])
+		directive.Add(expectedMap)
+		directive.Apply(expectedMap)
+		expected[i] = expectedMap
+		if i < len(actual) && actual[i] != nil {
+			actualMap := toolbox.AsMap(actual[i])
+			directive.Apply(actualMap)
+			actual[i] = actualMap
+		}
+	}
+	return expected, actual
+}
+
 func assertSlice(expected []interface{}, actualValue interface{}, path DataPath, context *Context, validation *Validation) error {
 	if actualValue == nil {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actualValue))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actualValue))
 		return nil
 	}
 	if toolbox.IsMap(actualValue) { //given that pairs of key/value makes a map
@@ -736,7 +945,7 @@ func assertSlice(expected []interface{}, actualValue interface{}, path DataPath,
 		}
 	}
 	if !toolbox.IsSlice(actualValue) {
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actualValue))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), IncompatibleDataTypeViolation, expected, actualValue))
 		return nil
 	}
 	var actual = toolbox.AsSlice(actualValue)
@@ -745,7 +954,7 @@ func assertSlice(expected []interface{}, actualValue interface{}, path DataPath,
 			validation.PassedCount++
 			return nil
 		}
-		validation.AddFailure(NewFailure(path.Source(), path.Path(), LengthViolation, len(expected), len(actual)))
+		validation.AddFailure(newFailure(path.Source(), path.Path(), LengthViolation, len(expected), len(actual)))
 		return nil
 	}
 
@@ -753,83 +962,99 @@ func assertSlice(expected []interface{}, actualValue interface{}, path DataPath,
 
 	if toolbox.IsMap(expected[0]) || toolbox.IsStruct(expected[0]) {
 		first := toolbox.AsMap(expected[0])
-		if directive.ExtractDirectives(first) {
+		if !isValueWrapper(first) && directive.extractEntries(context.directiveEntries(first)) {
 			expected = expected[1:]
 		}
-		if directive.SortText {
-			var expectedSlice = []string{}
-			toolbox.ProcessSlice(expected, func(item interface{}) bool {
-				expectedSlice = append(expectedSlice, toolbox.AsString(item))
-				return true
-			})
-			var actualSlice = []string{}
-			toolbox.ProcessSlice(expected, func(item interface{}) bool {
-				actualSlice = append(actualSlice, toolbox.AsString(item))
-				return true
-			})
-
-			sort.Strings(expectedSlice)
-			expected = []interface{}{}
-			for _, item := range expectedSlice {
-				expected = append(expected, item)
+		if expectedLength, ok := directive.removeLength(""); ok {
+			if err := assertLength(expectedLength, actual, path, validation); err != nil {
+				return err
 			}
-
-			sort.Strings(actualSlice)
-			actual = []interface{}{}
-			for _, item := range actualSlice {
-				actual = append(actual, item)
+		}
+		if schema, ok := directive.Schemas[""]; ok {
+			delete(directive.Schemas, "")
+			if err := assertSchema(schema, actual, path, validation); err != nil {
+				return err
 			}
-
-		} else {
-
-			if !directive.KeyCaseSensitive {
-				expected = asKeyCaseInsensitiveSlice(expected)
-				actual = asKeyCaseInsensitiveSlice(actual)
-				directive.ApplyKeyCaseInsensitive()
+		}
+		if name, ok := directive.Captures[""]; ok {
+			delete(directive.Captures, "")
+			unlock := context.lock()
+			variables := context.Variables()
+			unlock()
+			validation.addCapture(&capture{name: name, value: actual, variables: variables, mutex: context.mutex})
+		}
+		if len(directive.Exprs) > 0 {
+			expressions := directive.Exprs
+			directive.Exprs = nil
+			if err := assertExpressions(expressions, actual, path, context, validation); err != nil {
+				return err
 			}
-
-			if !directive.CaseSensitive {
-				expected = asValueCaseInsensitiveSlice(expected)
-				actual = asValueCaseInsensitiveSlice(actual)
+		}
+		if len(directive.Aggregates) > 0 {
+			aggregates := directive.Aggregates
+			directive.Aggregates = nil
+			if err := assertAggregates(aggregates, actual, path, context, validation); err != nil {
+				return err
 			}
-
-			for i := 0; i < len(actual); i++ {
-				var actualMap = toolbox.AsMap(actual[i])
-				directive.ExtractDataTypes(actualMap)
+		}
+		if directive.Unique || len(directive.UniqueBy) > 0 {
+			assertUnique(directive.UniqueBy, actual, path, validation)
+			directive.Unique, directive.UniqueBy = false, nil
+		}
+		if len(directive.SortedBy) > 0 {
+			sortedBy := directive.SortedBy
+			directive.SortedBy = nil
+			assertSorted(sortedBy, actual, path, context, validation)
+		}
+		if len(directive.Some)+len(directive.None)+len(directive.Counts) > 0 {
+			if err := assertQuantifiers(directive, actual, path, context, validation); err != nil {
+				return err
 			}
-
-			//add directive to expected
-			for i := 0; i < len(expected); i++ {
-				if expected[i] == nil {
-					if actual[i] == nil {
-						expected[i] = map[string]interface{}{}
-						actual[i] = map[string]interface{}{}
-					}
-				}
-				var expectedMap = toolbox.AsMap(expected[i])
-				directive.Add(expectedMap)
-				directive.Apply(expectedMap)
-				expected[i] = expectedMap
-				if i < len(actual) {
-					actualMap := toolbox.AsMap(actual[i])
-					directive.Apply(actualMap)
-					actual[i] = actualMap
-				}
+		}
+		if directive.SortText || len(directive.SortBy) > 0 {
+			sortKeys := parseSortKeys(directive.SortBy)
+			if len(sortKeys) == 0 {
+				sortKeys = []*sortKey{{}}
 			}
+			directive.SortText, directive.SortBy = false, nil
+			timeLayout := directive.DefaultTimeLayout()
+			expected = sortItems(expected, sortKeys, timeLayout)
+			actual = sortItems(actual, sortKeys, timeLayout)
+		}
+
+		if hasMapItems(expected) {
+			expected, actual = applySliceDirective(directive, expected, actual, context)
 
 			shouldIndex := len(directive.IndexBy) > 0
 			if shouldIndex {
 
 				expectedMap := indexSliceBy(expected, directive.IndexBy...)
+				assertIndexKeys(directive.IndexBy, actual, path, validation)
 				actualMap := indexSliceBy(actual, directive.IndexBy...)
 				return assertMap(expectedMap, actualMap, path, context, validation)
 			}
 		}
 	}
 
+	if directive.Align {
+		return assertAlignedItems(expected, actual, path, context, validation)
+	}
+	if workers := context.workers(len(expected)); workers > 1 {
+		var assertions = make([]*valueAssertion, 0, len(expected))
+		for i := 0; i < len(expected) && i < len(actual); i++ {
+			assertions = append(assertions, &valueAssertion{expected: expected[i], actual: actual[i], path: isolatePath(path.Index(i))})
+		}
+		if err := assertConcurrently(assertions, workers, context, validation); err != nil {
+			return err
+		}
+		if len(expected) > len(actual) {
+			validation.AddFailure(newFailure(path.Source(), path.Path(), LengthViolation, len(expected), len(actual)))
+		}
+		return nil
+	}
 	for i := 0; i < len(expected); i++ {
 		if i >= len(actual) {
-			validation.AddFailure(NewFailure(path.Source(), path.Path(), LengthViolation, len(expected), len(actual)))
+			validation.AddFailure(newFailure(path.Source(), path.Path(), LengthViolation, len(expected), len(actual)))
 			return nil
 		}
 		indexPath := path.Index(i)
@@ -1,6 +1,9 @@
 package assertly_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/assertly"
@@ -1782,3 +1785,1085 @@ func TestAssertWithAssertPath(t *testing.T) {
 	runUseCasesWithContext(t, useCases, context)
 
 }
+
+func TestAssertQuantifiers(t *testing.T) {
+	var actual = `[
+	{"id":1, "status":"ok", "amount":10},
+	{"id":2, "status":"failed", "amount":20},
+	{"id":3, "status":"ok", "amount":30}
+]`
+	var useCases = []*assertUseCase{
+		{
+			Description: "some item matched",
+			Expected:    `[{"@some@":{"status":"failed", "amount":20}}]`,
+			Actual:      actual,
+			PassedCount: 1,
+		},
+		{
+			Description: "none of items matched",
+			Expected:    `[{"@some@":{"status":"failed", "amount":30}}]`,
+			Actual:      actual,
+			FailedCount: 1,
+		},
+		{
+			Description: "some with multi templates",
+			Expected:    `[{"@some@":[{"id":1}, {"id":3}, {"id":4}]}]`,
+			Actual:      actual,
+			PassedCount: 2,
+			FailedCount: 1,
+		},
+		{
+			Description: "none item matched",
+			Expected:    `[{"@none@":{"status":"pending"}}]`,
+			Actual:      actual,
+			PassedCount: 1,
+		},
+		{
+			Description: "none violation for each matched item",
+			Expected:    `[{"@none@":{"status":"ok"}}]`,
+			Actual:      actual,
+			FailedCount: 2,
+		},
+		{
+			Description: "exact count",
+			Expected:    `[{"@count@":{"match":{"status":"ok"}, "count":2}}]`,
+			Actual:      actual,
+			PassedCount: 1,
+		},
+		{
+			Description: "at least count",
+			Expected:    `[{"@count@":{"match":{"amount":"/[15..40]/"}, "count":">=3"}}]`,
+			Actual:      actual,
+			FailedCount: 1,
+		},
+		{
+			Description: "count with range",
+			Expected:    `[{"@count@":{"match":{"status":"!failed"}, "count":"/[1..2]/"}}]`,
+			Actual:      actual,
+			PassedCount: 1,
+		},
+		{
+			Description: "invalid count expression",
+			Expected:    `[{"@count@":{"match":{"status":"ok"}, "count":"abc"}}]`,
+			Actual:      actual,
+			HasError:    true,
+		},
+		{
+			Description: "quantifier with positional items",
+			Expected:    `[{"@some@":{"id":3}}, {"id":1}, {"id":2}]`,
+			Actual:      actual,
+			PassedCount: 3,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertQuantifiers_ClosestItem(t *testing.T) {
+	var actual = []interface{}{
+		map[string]interface{}{"id": 1, "status": "failed", "amount": 10},
+		map[string]interface{}{"id": 2, "status": "ok", "amount": 20},
+	}
+	var expected = []interface{}{
+		assertly.TestDirective{}.Some(map[string]interface{}{"status": "ok", "amount": 30}),
+	}
+	validation, err := assertly.Assert(expected, actual, assertly.NewDataPath("/"))
+	assert.Nil(t, err)
+	if assert.EqualValues(t, 1, validation.FailedCount) {
+		failure := validation.Failures[0]
+		assert.EqualValues(t, assertly.SomeViolation, failure.Reason)
+		assert.EqualValues(t, 1, failure.Args[1])
+		assert.Contains(t, failure.Message, "closest item[1]")
+		assert.Contains(t, failure.Message, "[/]:[1].amount")
+	}
+}
+
+func TestAssertLength(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "exact length",
+			Expected:    `{"@length@items":3}`,
+			Actual:      `{"items":[1,2,3]}`,
+			PassedCount: 1,
+		},
+		{
+			Description: "length range",
+			Expected:    `{"@length@items":"/[1..10]/", "@length@name":"/[1..3]/"}`,
+			Actual:      `{"items":[1,2,3], "name":"abcd"}`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "length comparison",
+			Expected:    `{"@length@items":">0", "@length@attrs":"<=1", "@length@tags":"!0"}`,
+			Actual:      `{"items":[1], "attrs":{"k1":1, "k2":2}, "tags":[]}`,
+			PassedCount: 1,
+			FailedCount: 2,
+		},
+		{
+			Description: "current map node length",
+			Expected:    `{"@length@":">=2", "k1":1}`,
+			Actual:      `{"k1":1, "k2":2}`,
+			PassedCount: 2,
+		},
+		{
+			Description: "current slice node length",
+			Expected:    `[{"@length@":">0"}]`,
+			Actual:      `[{"id":1}, {"id":2}]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "empty slice node length",
+			Expected:    `[{"@length@":">0"}]`,
+			Actual:      `[]`,
+			FailedCount: 1,
+		},
+		{
+			Description: "invalid length expression",
+			Expected:    `{"@length@items":">abc"}`,
+			Actual:      `{"items":[1]}`,
+			HasError:    true,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertDataType(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "matched data types",
+			Expected:    `{"@type@id":"int", "@type@name":"string", "@type@active":"bool", "@type@attrs":"object", "@type@tags":"array", "@type@parent":"null", "@type@attrs.k1":"number"}`,
+			Actual:      `{"id":1, "name":"abc", "active":true, "attrs":{"k1":1.5}, "tags":[], "parent":null}`,
+			PassedCount: 7,
+		},
+		{
+			Description: "mismatched data types",
+			Expected:    `{"@type@id":"number", "@type@ratio":"int", "@type@name":"string|null"}`,
+			Actual:      `{"id":"1", "ratio":1.5, "name":null}`,
+			PassedCount: 1,
+			FailedCount: 2,
+		},
+		{
+			Description: "missing entry",
+			Expected:    `{"@type@id":"number"}`,
+			Actual:      `{"name":"abc"}`,
+			FailedCount: 1,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertFloatExpression(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "range and negation against float64",
+			Expected:    map[string]interface{}{"score": "/[1..3]/", "ratio": "!2"},
+			Actual:      map[string]interface{}{"score": 2.5, "ratio": 3.0},
+			PassedCount: 2,
+		},
+		{
+			Description: "range and negation violations against float64",
+			Expected:    map[string]interface{}{"score": "/[1..3]/", "ratio": "!3"},
+			Actual:      map[string]interface{}{"score": 3.5, "ratio": 3.0},
+			FailedCount: 2,
+		},
+		{
+			Description: "exact float64",
+			Expected:    map[string]interface{}{"score": 2.5, "ratio": "3"},
+			Actual:      map[string]interface{}{"score": 2.5, "ratio": 3.1},
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertNoImplicitCasting(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "numeric id returned as string",
+			Expected:    map[string]interface{}{"id": 1, "name": "abc"},
+			Actual:      map[string]interface{}{"id": "1", "name": "abc"},
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "text returned as number",
+			Expected:    map[string]interface{}{"id": "1"},
+			Actual:      map[string]interface{}{"id": 1.0},
+			FailedCount: 1,
+		},
+		{
+			Description: "text returned as bool",
+			Expected:    map[string]interface{}{"active": "true", "ratio": " 1.5 "},
+			Actual:      map[string]interface{}{"active": true, "ratio": 1.5},
+			FailedCount: 2,
+		},
+		{
+			Description: "bool returned as string",
+			Expected:    map[string]interface{}{"active": true},
+			Actual:      map[string]interface{}{"active": "true"},
+			FailedCount: 1,
+		},
+		{
+			Description: "expressions are not strict",
+			Expected:    map[string]interface{}{"id": "!2", "code": "/[1..3]/", "name": "~/\\w+/"},
+			Actual:      map[string]interface{}{"id": 1, "code": 2, "name": "abc"},
+			PassedCount: 3,
+		},
+		{
+			Description: "compatible numeric types",
+			Expected:    map[string]interface{}{"id": 1, "amount": 1.5},
+			Actual:      map[string]interface{}{"id": int64(1), "amount": float32(1.5)},
+			PassedCount: 2,
+		},
+	}
+	context := assertly.NewDefaultContext()
+	context.NoImplicitCasting = true
+	runUseCasesWithContext(t, useCases, context)
+}
+
+func TestAssertSchema(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "inline schema with values",
+			Expected: `{
+	"@schema@": {"type":"object", "required":["id"], "properties": {"id":{"type":"integer"}, "tags":{"type":"array", "items":{"type":"string"}}}},
+	"id": 1
+}`,
+			Actual:      `{"id":1, "tags":["a", "b"]}`,
+			PassedCount: 2,
+		},
+		{
+			Description: "inline schema violations",
+			Expected: `{
+	"@schema@": {"type":"object", "required":["id"], "properties": {"tags":{"type":"array", "items":{"type":"string"}}}},
+	"name": "abc"
+}`,
+			Actual:      `{"name":"abc", "tags":["a", 2]}`,
+			PassedCount: 1,
+			FailedCount: 2,
+		},
+		{
+			Description: "schema file",
+			Expected:    `{"@schema@":"test/schema/order.json"}`,
+			Actual:      `{"id":1, "status":"paid", "items":[{"sku":"a1", "quantity":2}]}`,
+			PassedCount: 1,
+		},
+		{
+			Description: "schema file violations",
+			Expected:    `{"@schema@":"test/schema/order.json", "status":"paid"}`,
+			Actual:      `{"id":"1", "status":"lost", "items":[{"sku":"a1", "quantity":0}, {"quantity":1}]}`,
+			FailedCount: 5,
+		},
+		{
+			Description: "sub path schema",
+			Expected:    `{"@schema@items":{"type":"array", "maxItems":1}, "@schema@meta":{"type":"object"}}`,
+			Actual:      `{"items":[1, 2]}`,
+			FailedCount: 2,
+		},
+		{
+			Description: "slice schema",
+			Expected:    `[{"@schema@":{"type":"array", "items":{"type":"integer"}}}]`,
+			Actual:      `[1, 2, 3]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "invalid schema",
+			Expected:    `{"@schema@":{"type":12}}`,
+			Actual:      `{"id":1}`,
+			HasError:    true,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertSchema_FailurePath(t *testing.T) {
+	var expected = map[string]interface{}{
+		assertly.SchemaDirective: "test/schema/order.json",
+	}
+	var actual = map[string]interface{}{
+		"id":    1,
+		"items": []interface{}{map[string]interface{}{"sku": "a1", "quantity": 1}, map[string]interface{}{"sku": "a2", "quantity": 0}},
+	}
+	validation, err := assertly.Assert(expected, actual, assertly.NewDataPath("/"))
+	assert.Nil(t, err)
+	if assert.EqualValues(t, 1, validation.FailedCount) {
+		assert.EqualValues(t, "[/]:items[1].quantity", validation.Failures[0].Path)
+		assert.EqualValues(t, assertly.SchemaViolation, validation.Failures[0].Reason)
+	}
+}
+
+func TestAssertCapture(t *testing.T) {
+	context := assertly.NewDefaultContext()
+	{ //capture with macro
+		validation, err := assertly.AssertWithContext(`{"id":"<ds:capture[\"orderId\"]>", "status":"new"}`, `{"id":101, "status":"new"}`, assertly.NewDataPath("response"), context)
+		assert.Nil(t, err)
+		assert.EqualValues(t, 2, validation.PassedCount)
+		assert.EqualValues(t, 0, validation.FailedCount)
+	}
+	{ //capture with directive
+		validation, err := assertly.AssertWithContext(`{"@capture@customer.id":"customerId"}`, `{"customer":{"id":"c1"}}`, assertly.NewDataPath("response"), context)
+		assert.Nil(t, err)
+		assert.EqualValues(t, 0, validation.FailedCount)
+	}
+	assert.EqualValues(t, 101, context.Variables()["orderId"])
+	assert.EqualValues(t, "c1", context.Variables()["customerId"])
+
+	{ //captures made while matching candidates are stored only for the selected match
+		validation, err := assertly.AssertWithContext(`[{"@some@":{"id":"<ds:capture[\"someId\"]>", "status":"failed"}}]`, `[{"id":1, "status":"ok"}, {"id":2, "status":"failed"}, {"id":3, "status":"ok"}]`, assertly.NewDataPath("some"), context)
+		assert.Nil(t, err)
+		assert.EqualValues(t, 0, validation.FailedCount)
+		assert.EqualValues(t, 2, context.Variables()["someId"])
+
+		validation, err = assertly.AssertWithContext(`{"@oneOf@":[{"id":"<ds:capture[\"oneOfId\"]>", "type":"a"}, {"code":"<ds:capture[\"oneOfCode\"]>", "type":"b"}]}`, `{"id":7, "code":"x", "type":"b"}`, assertly.NewDataPath("oneOf"), context)
+		assert.Nil(t, err)
+		assert.EqualValues(t, 0, validation.FailedCount)
+		assert.EqualValues(t, "x", context.Variables()["oneOfCode"])
+		_, ok := context.Variables()["oneOfId"]
+		assert.False(t, ok, "failed candidate capture should not be stored")
+
+		_, err = assertly.AssertWithContext(`[{"@align@":true}, {"name":"x"}, {"id":"<ds:capture[\"alignId\"]>", "name":"b"}, {"name":"z"}]`, `[{"id":1, "name":"a"}, {"id":2, "name":"b"}, {"id":3, "name":"c"}]`, assertly.NewDataPath("align"), context)
+		assert.Nil(t, err)
+		assert.EqualValues(t, 2, context.Variables()["alignId"])
+
+		_, err = assertly.AssertWithContext(`{"@oneOf@":[[{"@capture@":"oneOfItems", "@length@":3}], [{"@length@":2}]]}`, `[1, 2]`, assertly.NewDataPath("oneOfSlice"), context)
+		assert.Nil(t, err)
+		_, ok = context.Variables()["oneOfItems"]
+		assert.False(t, ok, "failed candidate slice capture should not be stored")
+	}
+
+	var useCases = []*assertUseCase{
+		{
+			Description: "captured values references",
+			Expected:    `[{"@indexBy@":"id"}, {"id":1, "order_id":"<ds:var[\"orderId\"]>", "customer_id":"<ds:var[\"customerId\"]>"}]`,
+			Actual:      `[{"id":1, "order_id":101, "customer_id":"c1"}]`,
+			PassedCount: 3,
+		},
+		{
+			Description: "captured value mismatch",
+			Expected:    `{"order_id":"<ds:var[\"orderId\"]>"}`,
+			Actual:      `{"order_id":102}`,
+			FailedCount: 1,
+		},
+		{
+			Description: "missing variable",
+			Expected:    `{"order_id":"<ds:var[\"invoiceId\"]>"}`,
+			Actual:      `{"order_id":102}`,
+			HasError:    true,
+		},
+		{
+			Description: "missing capture key",
+			Expected:    `{"@capture@invoice.id":"invoiceId"}`,
+			Actual:      `{"order_id":102}`,
+			FailedCount: 1,
+		},
+	}
+	runUseCasesWithContext(t, useCases, context)
+}
+
+func TestAssertExpr(t *testing.T) {
+	var actual = `{
+	"startTime": "2019-03-11T02:20:33Z",
+	"endTime": "2019-03-11T03:20:33Z",
+	"price": 20,
+	"discount": 5,
+	"total": 35.5,
+	"type": "refund",
+	"items": [{"amount": 10}, {"amount": 20.5}, {"amount": 5}]
+}`
+	var useCases = []*assertUseCase{
+		{
+			Description: "passed expressions",
+			Expected: `{
+	"@expr@": ["endTime > startTime", "discount <= price", "total == sum(items.amount)"],
+	"@expr@avg": "avg(items.amount) > 10 && max(items.amount) == 20.5 and count(items) == 3",
+	"type": "refund"
+}`,
+			Actual:      actual,
+			PassedCount: 5,
+		},
+		{
+			Description: "failed expressions",
+			Expected:    `{"@expr@": ["startTime >= endTime", "(price - discount) * 2 < 30 || type != 'refund'", "!(min(items.amount) == 5)"]}`,
+			Actual:      actual,
+			FailedCount: 3,
+		},
+		{
+			Description: "slice expressions",
+			Expected:    `[{"@expr@": "sum(amount) == 35.5 && count(amount) == 3"}]`,
+			Actual:      `[{"amount": 10}, {"amount": 20.5}, {"amount": 5}]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "invalid expression",
+			Expected:    `{"@expr@": "price >"}`,
+			Actual:      actual,
+			HasError:    true,
+		},
+		{
+			Description: "missing operand",
+			Expected:    `{"@expr@": "end - start > 0"}`,
+			Actual:      `{"end":5}`,
+			FailedCount: 1,
+		},
+		{
+			Description: "non numeric operand and division by zero",
+			Expected:    `{"@expr@": ["price * 2 > 10", "total / (total - total) > 1", "discount < price"]}`,
+			Actual:      `{"price":"abc", "total":10, "discount":"a"}`,
+			PassedCount: 1,
+			FailedCount: 2,
+		},
+		{
+			Description: "non bool expression",
+			Expected:    `{"@expr@": "price + 1"}`,
+			Actual:      actual,
+			HasError:    true,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertExpr_Operands(t *testing.T) {
+	var expected = assertly.TestDirective{}.Expr("total == sum(items.amount)")
+	var actual = map[string]interface{}{
+		"total": 30,
+		"items": []interface{}{map[string]interface{}{"amount": 10}, map[string]interface{}{"amount": 15}},
+	}
+	validation, err := assertly.Assert(expected, actual, assertly.NewDataPath("/"))
+	assert.Nil(t, err)
+	if assert.EqualValues(t, 1, validation.FailedCount) {
+		assert.EqualValues(t, assertly.PredicateViolation, validation.Failures[0].Reason)
+		assert.EqualValues(t, map[string]interface{}{"total": 30, "sum(items.amount)": 25.0}, validation.Failures[0].Actual)
+	}
+}
+
+func TestAssertAggregates(t *testing.T) {
+	var actual = `[
+	{"userId":1, "amount":10.25, "ts":"2019-03-11T02:20:33Z"},
+	{"userId":2, "amount":20.5, "ts":"2019-03-15T12:07:33Z"},
+	{"userId":1, "amount":5, "ts":"2019-03-12T05:15:33Z"}
+]`
+	var useCases = []*assertUseCase{
+		{
+			Description: "matched aggregates",
+			Expected:    `[{"@sum@amount":35.75, "@min@amount":5, "@max@ts":"2019-03-15T12:07:33Z", "@distinct@userId":2}]`,
+			Actual:      actual,
+			PassedCount: 4,
+		},
+		{
+			Description: "mismatched aggregates",
+			Expected:    `[{"@sum@amount":35, "@max@amount":"<20", "@distinct@userId":3, "@min@ts":"2019-03-11T02:20:33Z"}]`,
+			Actual:      actual,
+			PassedCount: 1,
+			FailedCount: 3,
+		},
+		{
+			Description: "aggregates with numeric precision",
+			Expected:    `[{"@numericPrecisionPoint@":0, "@sum@amount":36, "@avg@amount":12}]`,
+			Actual:      actual,
+			PassedCount: 2,
+		},
+		{
+			Description: "map aggregates",
+			Expected:    `{"@sum@items.amount":30, "@max@items.amount":20, "total":30}`,
+			Actual:      `{"total":30, "items":[{"amount":10}, {"amount":20}]}`,
+			PassedCount: 3,
+		},
+		{
+			Description: "non numeric sum",
+			Expected:    `[{"@sum@name":1}]`,
+			Actual:      `[{"name":"abc"}]`,
+			HasError:    true,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertUnique(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "unique items",
+			Expected:    `[{"@unique@":true}]`,
+			Actual:      `[1, 2, 3]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "duplicated items",
+			Expected:    `[{"@unique@":true}]`,
+			Actual:      `[1, 2, 1, 3, 1]`,
+			FailedCount: 2,
+		},
+		{
+			Description: "unique by field",
+			Expected:    `[{"@uniqueBy@":"id"}, {"id":1}, {"id":2}]`,
+			Actual:      `[{"id":1, "name":"a"}, {"id":2, "name":"a"}]`,
+			PassedCount: 3,
+		},
+		{
+			Description: "duplicated by fields",
+			Expected:    `[{"@uniqueBy@":["pid", "id"]}]`,
+			Actual:      `[{"pid":1, "id":1}, {"pid":1, "id":2}, {"pid":1, "id":1}]`,
+			FailedCount: 1,
+		},
+		{
+			Description: "duplicated by fields without concatenation collision",
+			Expected:    `[{"@uniqueBy@":"a,b"}]`,
+			Actual:      `[{"a":"1", "b":"23"}, {"a":"12", "b":"3"}]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "duplicated index key",
+			Expected:    `[{"@indexBy@":"id"}, {"id":1, "name":"a"}, {"id":2, "name":"b"}]`,
+			Actual:      `[{"id":1, "name":"a"}, {"id":2, "name":"b"}, {"id":1, "name":"c"}]`,
+			PassedCount: 3,
+			FailedCount: 2,
+		},
+		{
+			Description: "duplicated index key in map",
+			Expected:    `{"@indexBy@":"id", "1":{"name":"a"}}`,
+			Actual:      `[{"id":1, "name":"a"}, {"id":1, "name":"a"}]`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertSortedBy(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "sorted scalar items",
+			Expected:    `[{"@sortedBy@":"asc"}]`,
+			Actual:      `[1, 2, 10, 10]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "numeric rather than text collation",
+			Expected:    `[{"@sortedBy@":"desc"}]`,
+			Actual:      `[10, 9, 1]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "sorted by multiple keys",
+			Expected:    `[{"@sortedBy@":"category, price desc"}]`,
+			Actual:      `[{"category":"a", "price":3}, {"category":"a", "price":1.5}, {"category":"b", "price":7}]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "out of order by secondary key",
+			Expected:    `[{"@sortedBy@":["category", "-price"]}]`,
+			Actual:      `[{"category":"a", "price":1}, {"category":"a", "price":3}, {"category":"b", "price":2}, {"category":"a", "price":1}]`,
+			FailedCount: 1,
+		},
+		{
+			Description: "sorted by time",
+			Expected:    `[{"@sortedBy@":"ts desc"}]`,
+			Actual:      `[{"ts":"2019-03-15T12:07:33Z"}, {"ts":"2019-03-12T05:15:33Z"}, {"ts":"2019-03-12T05:15:33+02:00"}]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "sorted with nil first",
+			Expected:    `[{"@sortedBy@":"name"}]`,
+			Actual:      `[{"id":1}, {"name":"a"}, {"name":"b"}]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "text collation",
+			Expected:    `[{"@sortedBy@":"zip:text, id:number desc"}]`,
+			Actual:      `[{"zip":"02110", "id":"10"}, {"zip":"02110", "id":"9"}, {"zip":"1000"}, {"zip":"9"}]`,
+			PassedCount: 1,
+		},
+		{
+			Description: "text collation violation",
+			Expected:    `[{"@sortedBy@":":text"}]`,
+			Actual:      `["10", "9", "1"]`,
+			FailedCount: 1,
+		},
+		{
+			Description: "mixed values ordered numbers, time then text",
+			Expected:    `[{"@sortedBy@":"v"}]`,
+			Actual:      `[{"v":2}, {"v":"10"}, {"v":"2019-03-12T05:15:33Z"}, {"v":"a"}, {"v":"b"}]`,
+			PassedCount: 1,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertSortBy(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "sort mixed values",
+			Expected:    `[{"@sortBy@":"v"}, {"v":"b"}, {"v":10}, {"v":"a"}, {"v":2}, {"v":"c"}]`,
+			Actual:      `[{"v":"a"}, {"v":"c"}, {"v":2}, {"v":"b"}, {"v":10}]`,
+			PassedCount: 5,
+		},
+		{
+			Description: "sort text compares actual items",
+			Expected:    `[{"@sortText@":true}, "abc", "ax5", "z523"]`,
+			Actual:      `["ax5", "z523", "abd"]`,
+			PassedCount: 2,
+			FailedCount: 1,
+		},
+		{
+			Description: "sort scalar items",
+			Expected:    `[{"@sortBy@":true}, 10, 1, 2]`,
+			Actual:      `[10, 2, 1]`,
+			PassedCount: 3,
+		},
+		{
+			Description: "sort map items by key",
+			Expected:    `[{"@sortBy@":"id"}, {"id":1, "name":"a"}, {"id":2, "name":"b"}, {"id":10, "name":"c"}]`,
+			Actual:      `[{"id":10, "name":"c"}, {"id":1, "name":"a"}, {"id":2, "name":"x"}]`,
+			PassedCount: 5,
+			FailedCount: 1,
+		},
+		{
+			Description: "sort map items by multiple keys",
+			Expected:    `[{"@sortBy@":"k1,k2 desc"}, {"k1":1, "k2":2, "v":"b"}, {"k1":1, "k2":1, "v":"a"}, {"k1":2, "k2":1, "v":"c"}]`,
+			Actual:      `[{"k1":2, "k2":1, "v":"c"}, {"k1":1, "k2":1, "v":"a"}, {"k1":1, "k2":2, "v":"b"}]`,
+			PassedCount: 9,
+		},
+		{
+			Description: "sort map items by canonical JSON",
+			Expected:    `[{"@sortBy@":true}, {"b":1, "a":2}, {"a":1, "c":[1,2]}]`,
+			Actual:      `[{"c":[1,2], "a":1}, {"a":2, "b":1}]`,
+			PassedCount: 5,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertConditions(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "when condition matched",
+			Expected:    `{"@when@type == 'refund'":{"amount":"/[-100..0]/"}, "type":"refund"}`,
+			Actual:      `{"type":"refund", "amount":-10}`,
+			PassedCount: 2,
+		},
+		{
+			Description: "when condition not matched",
+			Expected:    `{"@when@type == 'refund'":{"amount":"/[-100..0]/"}, "type":"/sale/"}`,
+			Actual:      `{"type":"sale", "amount":10}`,
+			PassedCount: 1,
+		},
+		{
+			Description: "if then else",
+			Expected:    `{"@if@":{"when":"type == 'refund'", "then":{"amount":"/[-100..0]/"}, "else":{"amount":"/[0..100]/"}}}`,
+			Actual:      `{"type":"sale", "amount":-10}`,
+			FailedCount: 1,
+		},
+		{
+			Description: "nested conditions",
+			Expected:    `{"@if@":[{"when":"type == 'refund'", "then":{"@when@amount < -50":{"approved":true}, "amount":"!/[0..1000]/"}}]}`,
+			Actual:      `{"type":"refund", "amount":-100, "approved":false}`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "conditions with switch case",
+			Expected: `{
+	"@switchCaseBy@":"type",
+	"@when@amount > 100":{"approved":true},
+	"refund":{"amount":"!/[0..1000]/"},
+	"sale":{"amount":"/[0..1000]/"},
+	"shared":{"@when@country == 'US'":{"tax":"/[1..100]/"}}
+}`,
+			Actual:      `{"type":"sale", "amount":200, "approved":true, "country":"US", "tax":0}`,
+			PassedCount: 2,
+			FailedCount: 1,
+		},
+		{
+			Description: "condition with missing operand",
+			Expected:    `{"@when@amount / count > 10":{"approved":true}, "type":"sale"}`,
+			Actual:      `{"type":"sale", "amount":100}`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "invalid condition",
+			Expected:    `{"@when@type ==":{"amount":1}}`,
+			Actual:      `{"type":"refund", "amount":1}`,
+			HasError:    true,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertAlternatives(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "any of documents matched",
+			Expected:    `{"@anyOf@":[{"status":"ok", "items":"@exists@"}, {"status":"partial", "errors":"@exists@"}]}`,
+			Actual:      `{"status":"partial", "errors":["timeout"]}`,
+			PassedCount: 1,
+		},
+		{
+			Description: "any of values matched",
+			Expected:    `{"status":{"@anyOf@":["ok", "partial"]}, "id":1}`,
+			Actual:      `{"status":"partial", "id":1}`,
+			PassedCount: 2,
+		},
+		{
+			Description: "any of documents not matched, closest candidate reported",
+			Expected:    `{"@anyOf@":[{"status":"ok", "id":2, "name":"x"}, {"status":"partial", "id":1}]}`,
+			Actual:      `{"status":"failed", "id":1}`,
+			FailedCount: 2,
+		},
+		{
+			Description: "one of matched",
+			Expected:    `[{"@oneOf@":[{"id":1}, {"id":2}]}, {"@oneOf@":[{"id":"/[1..3]/"}, {"id":"/[4..6]/"}]}]`,
+			Actual:      `[{"id":2}, {"id":5}]`,
+			PassedCount: 2,
+		},
+		{
+			Description: "one of ambiguous",
+			Expected:    `{"@oneOf@":[{"id":1}, {"id":"/[1..3]/"}]}`,
+			Actual:      `{"id":1}`,
+			FailedCount: 1,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertKeyPatterns(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "regexp key pattern",
+			Expected:    `{"~/^metric_.*/":"/[1..100]/", "name":"abc"}`,
+			Actual:      `{"metric_1":10, "metric_2":20, "name":"abc"}`,
+			PassedCount: 3,
+		},
+		{
+			Description: "glob key pattern",
+			Expected:    `{"~2019-03-*":{"status":"ok"}}`,
+			Actual:      `{"2019-03-01":{"status":"ok"}, "2019-03-02":{"status":"failed"}, "2019-04-01":{"status":"failed"}}`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "explicit key precedence",
+			Expected:    `{"~/^metric_.*/":"/[1..10]/", "metric_2":20}`,
+			Actual:      `{"metric_1":10, "metric_2":20}`,
+			PassedCount: 2,
+		},
+		{
+			Description: "no matching key",
+			Expected:    `{"~/^metric_.*/":"/[1..10]/"}`,
+			Actual:      `{"name":"abc"}`,
+			FailedCount: 1,
+		},
+		{
+			Description: "key count",
+			Expected:    `{"@keyCount@~/^metric_.*/":"/[2..3]/", "@keyCount@~id_*":0, "~/^metric_.*/":"@exists@"}`,
+			Actual:      `{"metric_1":10, "metric_2":20, "name":"abc"}`,
+			PassedCount: 2,
+		},
+		{
+			Description: "key count violation",
+			Expected:    `{"@keyCount@~/^metric_.*/":1, "~/^metric_.*/":"/[1..100]/"}`,
+			Actual:      `{"metric_1":10, "metric_2":20}`,
+			FailedCount: 1,
+		},
+		{
+			Description: "pattern with does not exist",
+			Expected:    `{"~/^tmp_.*/":"@!exists@"}`,
+			Actual:      `{"tmp_1":1}`,
+			FailedCount: 1,
+		},
+		{
+			Description: "strict map check with key pattern",
+			Expected:    `{"@strictMapCheck@":true, "~/^metric_.*/":"/[1..100]/"}`,
+			Actual:      `{"metric_1":10, "name":"abc"}`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "invalid key pattern",
+			Expected:    `{"~/^metric_(/":1}`,
+			Actual:      `{"metric_1":10}`,
+			HasError:    true,
+		},
+		{
+			Description: "key count without pattern",
+			Expected:    `{"@keyCount@":2}`,
+			Actual:      `{"metric_1":10}`,
+			HasError:    true,
+		},
+		{
+			Description: "key count with literal key",
+			Expected:    `{"@keyCount@metric_*":2}`,
+			Actual:      `{"metric_1":10, "metric_2":20}`,
+			HasError:    true,
+		},
+		{
+			Description: "literal key starting with tilde",
+			Expected:    `{"~tmp":1, "~":2}`,
+			Actual:      `{"~tmp":1, "~":2, "tmp":3}`,
+			PassedCount: 2,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertOptionalAndNullable(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "optional value missing",
+			Expected:    `{"id":1, "name":{"@optional@":"abc"}}`,
+			Actual:      `{"id":1}`,
+			PassedCount: 2,
+		},
+		{
+			Description: "optional value present",
+			Expected:    `{"id":1, "name":{"@optional@":"abc"}}`,
+			Actual:      `{"id":1, "name":"xyz"}`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "optional value null",
+			Expected:    `{"id":1, "name":{"@optional@":"abc"}}`,
+			Actual:      `{"id":1, "name":null}`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "nullable value",
+			Expected:    `{"id":1, "name":{"@nullable@":"abc"}, "tags":{"@nullable@":["a"]}}`,
+			Actual:      `{"id":1, "name":null, "tags":["a"]}`,
+			PassedCount: 3,
+		},
+		{
+			Description: "nullable value missing",
+			Expected:    `{"id":1, "name":{"@nullable@":"abc"}}`,
+			Actual:      `{"id":1}`,
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "optional and nullable",
+			Expected:    `{"a":{"@optional@":{"@nullable@":{"x":1}}}, "b":{"@optional@":{"@nullable@":{"x":1}}}, "c":{"@nullable@":{"@optional@":{"x":1}}}}`,
+			Actual:      `{"b":null, "c":{"x":2}}`,
+			PassedCount: 2,
+			FailedCount: 1,
+		},
+		{
+			Description: "optional and nullable directives",
+			Expected:    `{"@optional@name":true, "@nullable@price":true, "name":"abc", "price":10}`,
+			Actual:      `{"price":null}`,
+			PassedCount: 2,
+		},
+		{
+			Description: "nullable slice items",
+			Expected:    `[{"@nullable@":{"id":1}}, {"@nullable@":{"id":2}}]`,
+			Actual:      `[null, {"id":2}]`,
+			PassedCount: 2,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertNormalize(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "line endings and trim",
+			Expected:    map[string]interface{}{"@normalize@": "lineEndings,trim", "text": "line 1\nline 2"},
+			Actual:      map[string]interface{}{"text": "line 1\r\nline 2\r\n"},
+			PassedCount: 1,
+		},
+		{
+			Description: "without normalization",
+			Expected:    map[string]interface{}{"text": "line 1\nline 2"},
+			Actual:      map[string]interface{}{"text": "line 1\r\nline 2\r\n"},
+			FailedCount: 1,
+		},
+		{
+			Description: "collapse whitespace for key",
+			Expected:    map[string]interface{}{"@normalize@text": "whitespace", "text": "a b c", "other": "a b"},
+			Actual:      map[string]interface{}{"text": "a  b\t\n c", "other": "a  b"},
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+		{
+			Description: "blank lines",
+			Expected:    map[string]interface{}{"@normalize@": "blankLines,lineEndings", "text": "a\nb"},
+			Actual:      map[string]interface{}{"text": "a\r\n\r\n  \nb"},
+			PassedCount: 1,
+		},
+		{
+			Description: "unicode normalization",
+			Expected:    map[string]interface{}{"@normalize@": "nfc", "text": "café", "fragment": "/é/"},
+			Actual:      map[string]interface{}{"text": "café", "fragment": "café"},
+			PassedCount: 2,
+		},
+		{
+			Description: "inherited normalization with regexp",
+			Expected:    map[string]interface{}{"@normalize@": "whitespace", "item": map[string]interface{}{"text": "~/^a b$/"}},
+			Actual:      map[string]interface{}{"item": map[string]interface{}{"text": "a   b"}},
+			PassedCount: 1,
+		},
+		{
+			Description: "slice normalization",
+			Expected:    []interface{}{map[string]interface{}{"@normalize@": "trim"}, "a", "b"},
+			Actual:      []interface{}{" a ", "b\n"},
+			PassedCount: 2,
+		},
+		{
+			Description: "unsupported normalization",
+			Expected:    map[string]interface{}{"@normalize@": "abc", "text": "a"},
+			Actual:      map[string]interface{}{"text": " a"},
+			HasError:    true,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertAlign(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "inserted item",
+			Expected:    `[{"@align@":true}, {"id":1}, {"id":2}, {"id":3}]`,
+			Actual:      `[{"id":0}, {"id":1}, {"id":2}, {"id":3}]`,
+			PassedCount: 3,
+			FailedCount: 1,
+		},
+		{
+			Description: "removed item",
+			Expected:    `[{"@align@":true}, {"id":1}, {"id":2}, {"id":3}, {"id":4}]`,
+			Actual:      `[{"id":1}, {"id":3}, {"id":4}]`,
+			PassedCount: 3,
+			FailedCount: 1,
+		},
+		{
+			Description: "changed item",
+			Expected:    `[{"@align@":true}, {"id":1, "name":"a"}, {"id":2, "name":"b"}, {"id":3, "name":"c"}]`,
+			Actual:      `[{"id":1, "name":"a"}, {"id":2, "name":"x"}, {"id":3, "name":"c"}]`,
+			PassedCount: 5,
+			FailedCount: 1,
+		},
+		{
+			Description: "aligned with expressions",
+			Expected:    `[{"@align@":true}, "/[1..3]/", "~/^b/", "c"]`,
+			Actual:      `["x", "2", "bb", "c"]`,
+			PassedCount: 3,
+			FailedCount: 1,
+		},
+		{
+			Description: "expression item after inserted item",
+			Expected:    `[{"@align@":true}, {"id":1}, {"id":"/[2..3]/"}, {"id":4}]`,
+			Actual:      `[{"id":1}, {"id":9}, {"id":3}, {"id":4}]`,
+			PassedCount: 3,
+			FailedCount: 1,
+		},
+		{
+			Description: "positional without align",
+			Expected:    `[{"id":1}, {"id":2}, {"id":3}]`,
+			Actual:      `[{"id":0}, {"id":1}, {"id":2}, {"id":3}]`,
+			FailedCount: 3,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertDecode(t *testing.T) {
+	var gzipped = new(bytes.Buffer)
+	writer := gzip.NewWriter(gzipped)
+	_, _ = writer.Write([]byte(`{"id":1}`))
+	_ = writer.Close()
+	var useCases = []*assertUseCase{
+		{
+			Description: "embedded json",
+			Expected:    map[string]interface{}{"@decode@payload": "json", "payload": map[string]interface{}{"a": 1, "b": "x"}},
+			Actual:      map[string]interface{}{"payload": `{"a":1, "b":"x"}`},
+			PassedCount: 2,
+		},
+		{
+			Description: "base64 json",
+			Expected:    map[string]interface{}{"@decode@payload": "base64+json", "payload": map[string]interface{}{"a": 1}},
+			Actual:      map[string]interface{}{"payload": base64.StdEncoding.EncodeToString([]byte(`{"a":1}`))},
+			PassedCount: 1,
+		},
+		{
+			Description: "base64 text",
+			Expected:    map[string]interface{}{"@decode@token": "base64", "token": "user:secret"},
+			Actual:      map[string]interface{}{"token": base64.StdEncoding.EncodeToString([]byte("user:secret"))},
+			PassedCount: 1,
+		},
+		{
+			Description: "gzip base64",
+			Expected:    map[string]interface{}{"@decode@data": "gzip+base64", "data": map[string]interface{}{"id": 1}},
+			Actual:      map[string]interface{}{"data": base64.StdEncoding.EncodeToString(gzipped.Bytes())},
+			PassedCount: 1,
+		},
+		{
+			Description: "query string",
+			Expected:    map[string]interface{}{"@decode@query": "query", "query": map[string]interface{}{"q": "a b", "tag": []interface{}{"x", "y"}}},
+			Actual:      map[string]interface{}{"query": "?q=a+b&tag=x&tag=y"},
+			PassedCount: 3,
+		},
+		{
+			Description: "query string repeated values order",
+			Expected:    map[string]interface{}{"@decode@query": "query", "query": map[string]interface{}{"a": []interface{}{"1", "2"}, "b": []interface{}{"3"}}},
+			Actual:      map[string]interface{}{"query": "a=2&b=3&a=1"},
+			PassedCount: 3,
+		},
+		{
+			Description: "query string unordered expected values",
+			Expected:    map[string]interface{}{"@decode@query": "query", "query": map[string]interface{}{"tag": []interface{}{map[string]interface{}{"@sortText@": true}, "y", "x"}}},
+			Actual:      map[string]interface{}{"query": "tag=y&tag=x"},
+			PassedCount: 2,
+		},
+		{
+			Description: "query string values mismatch",
+			Expected:    map[string]interface{}{"@decode@query": "query", "query": map[string]interface{}{"a": []interface{}{"1", "3"}, "b": "4"}},
+			Actual:      map[string]interface{}{"query": "a=2&b=3&a=1"},
+			PassedCount: 1,
+			FailedCount: 2,
+		},
+		{
+			Description: "url encoded",
+			Expected:    map[string]interface{}{"@decode@redirect": "urlencoded", "redirect": "http://host/path?a=1"},
+			Actual:      map[string]interface{}{"redirect": "http%3A%2F%2Fhost%2Fpath%3Fa%3D1"},
+			PassedCount: 1,
+		},
+		{
+			Description: "decoding failure",
+			Expected:    map[string]interface{}{"@decode@payload": "json", "payload": map[string]interface{}{"a": 1}, "id": 1},
+			Actual:      map[string]interface{}{"payload": `{"a":1`, "id": 1},
+			PassedCount: 1,
+			FailedCount: 1,
+		},
+	}
+	runUseCases(t, useCases)
+}
+
+func TestAssertURL(t *testing.T) {
+	var useCases = []*assertUseCase{
+		{
+			Description: "url parts with unordered query",
+			Expected: `{"@decode@redirect":"url", "redirect":{
+	"scheme":"https",
+	"host":"example.com:8443",
+	"path":"/callback",
+	"query":{"state":"abc", "code":["~/^[0-9a-f]+$/"], "scope":["email", "profile"]}
+}}`,
+			Actual:      `{"redirect":"https://example.com:8443/callback?scope=profile&code=5f3a&state=abc&scope=email"}`,
+			PassedCount: 7,
+		},
+		{
+			Description: "url query mismatch",
+			Expected:    `{"@decode@link":"url", "link":{"hostname":"example.com", "port":"", "query":{"sig":"@exists@", "expires":["/[1000..2000]/"]}}}`,
+			Actual:      `{"link":"http://example.com/file?expires=3000&signature=x"}`,
+			PassedCount: 2,
+			FailedCount: 2,
+		},
+		{
+			Description: "invalid url",
+			Expected:    `{"@decode@link":"url", "link":{"host":"example.com"}}`,
+			Actual:      `{"link":"http://[::1"}`,
+			FailedCount: 1,
+		},
+	}
+	runUseCases(t, useCases)
+}
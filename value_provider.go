@@ -20,5 +20,7 @@ func init() {
 	ValueProviderRegistry.Register("weekday", toolbox.NewWeekdayProvider())
 	ValueProviderRegistry.Register("dob", toolbox.NewDateOfBirthrovider())
 	ValueProviderRegistry.Register("cat", toolbox.NewFileValueProvider(true))
+	ValueProviderRegistry.Register("capture", &captureValueProvider{})
+	ValueProviderRegistry.Register("var", &variableValueProvider{})
 
 }
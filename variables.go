@@ -0,0 +1,106 @@
+package assertly
+
+import (
+	"fmt"
+	"github.com/viant/toolbox"
+	"github.com/viant/toolbox/data"
+	"sync"
+)
+
+// Variables represents actual values captured during validation
+type Variables map[string]interface{}
+
+var variablesKey = (*Variables)(nil)
+
+// contextVariables returns variables from supplied context, variables are created if needed
+func contextVariables(context toolbox.Context) Variables {
+	if context.Contains(variablesKey) {
+		if variables, ok := context.GetOptional(variablesKey).(*Variables); ok && *variables != nil {
+			return *variables
+		}
+	}
+	var variables = &Variables{}
+	_ = context.Replace(variablesKey, variables)
+	return *variables
+}
+
+// capturePredicate represents a predicate capturing actual value into a variable
+type capturePredicate struct {
+	name      string
+	variables Variables
+	mutex     *sync.Mutex
+}
+
+func (p *capturePredicate) String() string {
+	return fmt.Sprintf("capture[%v]", p.name)
+}
+
+// Apply stores actual value, it always passes
+func (p *capturePredicate) Apply(value interface{}) bool {
+	p.capture(value).apply()
+	return true
+}
+
+func (p *capturePredicate) capture(value interface{}) *capture {
+	return &capture{name: p.name, value: value, variables: p.variables, mutex: p.mutex}
+}
+
+// capture represents actual value to be stored into a variable, captures made while matching candidates
+// are buffered on trial validation and stored only once the validation is merged
+type capture struct {
+	name      string
+	value     interface{}
+	variables Variables
+	mutex     *sync.Mutex
+}
+
+func (c *capture) apply() {
+	if c.mutex != nil {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+	}
+	c.variables[c.name] = c.value
+}
+
+type captureValueProvider struct{}
+
+func (*captureValueProvider) Get(context toolbox.Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, fmt.Errorf("expected 1 argument (variable name) but had: %v", len(arguments))
+	}
+	return &capturePredicate{name: toolbox.AsString(arguments[0]), variables: contextVariables(context), mutex: contextMutex(context)}, nil
+}
+
+type variableValueProvider struct{}
+
+func (*variableValueProvider) Get(context toolbox.Context, arguments ...interface{}) (interface{}, error) {
+	if len(arguments) != 1 {
+		return nil, fmt.Errorf("expected 1 argument (variable name) but had: %v", len(arguments))
+	}
+	name := toolbox.AsString(arguments[0])
+	value, ok := contextVariables(context)[name]
+	if !ok {
+		return nil, fmt.Errorf("variable %v was not captured", name)
+	}
+	return value, nil
+}
+
+func captureValues(captures map[string]string, actualValue interface{}, actual map[string]interface{}, path DataPath, context *Context, validation *Validation) {
+	unlock := context.lock()
+	variables := context.Variables()
+	unlock()
+	actualMap := data.Map(actual)
+	for key, name := range captures {
+		if key == "" {
+			validation.addCapture(&capture{name: name, value: actualValue, variables: variables, mutex: context.mutex})
+			continue
+		}
+		value, ok := actualMap.GetValue(key)
+		if !ok {
+			keyPath := path.Key(key)
+			validation.AddFailure(newFailure(keyPath.Source(), keyPath.Path(), KeyExistsViolation, key, actual))
+			continue
+		}
+		validation.addCapture(&capture{name: name, value: value, variables: variables, mutex: context.mutex})
+	}
+}